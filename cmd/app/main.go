@@ -2,17 +2,23 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/charmbracelet/log"
 	"github.com/twmb/franz-go/pkg/kgo"
 	"mojosoftware.dev/lazykafka/internal/app"
+	"mojosoftware.dev/lazykafka/internal/config"
 	kafkaadmin "mojosoftware.dev/lazykafka/internal/kafka_admin"
+	"mojosoftware.dev/lazykafka/internal/serde"
 	"mojosoftware.dev/lazykafka/internal/ui"
+	"mojosoftware.dev/lazykafka/structs"
 )
 
 type viewState int
@@ -20,22 +26,77 @@ type viewState int
 const (
 	viewTopicsList viewState = iota
 	viewTopicDetail
+	viewTopicConfig
+	viewTopicPartitions
+	viewGroups
+	viewGroupDetail
+	viewResetOffsets
+	viewBrokers
+	viewBrokerDetail
+	viewBrokerConfig
+	viewDisk
+	viewACLs
+	viewSCRAMUsers
+	viewQuotas
 )
 
+// tabs are the top-level views, switched between with the number keys.
+var tabs = []struct {
+	key  string
+	name string
+	view viewState
+}{
+	{"1", "Topics", viewTopicsList},
+	{"2", "Consumer Groups", viewGroups},
+	{"3", "Brokers", viewBrokers},
+	{"4", "Disk", viewDisk},
+	{"5", "ACLs", viewACLs},
+	{"6", "SCRAM Users", viewSCRAMUsers},
+	{"7", "Quotas", viewQuotas},
+}
+
 type model struct {
-	list             list.Model
-	bootstrapServers string
-	client           *kafkaadmin.Client
-	width            int
-	height           int
+	list     list.Model
+	topics   []app.TopicItem
+	profile  config.Profile
+	profiles []config.Profile
+	client   *kafkaadmin.Client
+	width    int
+	height   int
+
+	// topicSizes are the last sizes fetched, kept across topic list
+	// refreshes as they are slower to get.
+	topicSizes map[string]int64
 
 	// View state
-	currentView     viewState
-	topicViewModels map[string]*ui.TopicViewModel
-	activeConsumers map[string]context.CancelFunc
-	consumerCtx     context.Context
-	consumerCancel  context.CancelFunc
-	messageChan     chan *kgo.Record
+	currentView      viewState
+	topicViewModels  map[string]*ui.TopicViewModel
+	topicConfigView  *ui.ConfigViewModel
+	partitionsView   *ui.PartitionsViewModel
+	groupsView       *ui.GroupsViewModel
+	groupDetailView  *ui.GroupDetailViewModel
+	resetOffsetsView *ui.ResetOffsetsViewModel
+	brokersView      *ui.BrokersViewModel
+	brokerDetailView *ui.BrokerDetailViewModel
+	brokerConfigView *ui.ConfigViewModel
+	diskView         *ui.DiskViewModel
+	aclsView         *ui.ACLsViewModel
+	scramUsersView   *ui.SCRAMUsersViewModel
+	quotasView       *ui.QuotasViewModel
+	selectedBroker   int32
+	// configReturnView is where leaving the broker config panel goes back
+	// to, as it opens from both the brokers tab and a broker's detail.
+	configReturnView viewState
+	selectedGroup    string
+	activeConsumers  map[string]context.CancelFunc
+	consumerCtx      context.Context
+	consumerCancel   context.CancelFunc
+	messageChan      chan *kgo.Record
+	deserializers    *serde.Registry
+
+	// Topic list options
+	topicSort    app.TopicSort
+	showInternal bool
 
 	// Overlay
 	overlayMgr    app.OverlayManager
@@ -44,27 +105,687 @@ type model struct {
 	toastMgr app.ToastManager
 }
 
-func initialModel(bootstrapServers string, kafkaAdmin *kafkaadmin.Client) model {
+func initialModel(profile config.Profile, profiles []config.Profile, kafkaAdmin *kafkaadmin.Client, deserializers *serde.Registry) model {
 	l := list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0)
-	l.Title = "Topics"
+	l.Title = "Topics • sorted by name • internal hidden"
 	l.SetShowStatusBar(true)
 	l.SetFilteringEnabled(true)
 	l.SetShowHelp(false)
 
 	return model{
-		bootstrapServers: bootstrapServers,
-		client:           kafkaAdmin,
-		list:             l,
-		currentView:      viewTopicsList,
-		topicViewModels:  make(map[string]*ui.TopicViewModel),
-		activeConsumers:  make(map[string]context.CancelFunc),
-		toastMgr:         app.NewToastManager(),
-		overlayMgr:       app.NewOverlayManager(),
+		profile:         profile,
+		profiles:        profiles,
+		client:          kafkaAdmin,
+		list:            l,
+		currentView:     viewTopicsList,
+		topicViewModels: make(map[string]*ui.TopicViewModel),
+		activeConsumers: make(map[string]context.CancelFunc),
+		deserializers:   deserializers,
+		toastMgr:        app.NewToastManager(),
+		overlayMgr:      app.NewOverlayManager(),
+	}
+}
+
+// refreshTopicList re-applies the sort order and internal topic filter.
+func (m *model) refreshTopicList() {
+	m.list.SetItems(app.TopicListItems(m.topics, m.topicSort, m.showInternal))
+
+	internal := "hidden"
+	if m.showInternal {
+		internal = "shown"
 	}
+	m.list.Title = fmt.Sprintf("Topics • sorted by %s • internal %s", m.topicSort, internal)
 }
 
 func (m model) Init() tea.Cmd {
-	return app.FetchTopicsCmd(m.client)
+	return tea.Batch(app.FetchTopicsCmd(m.client), app.FetchTopicSizesCmd(m.client))
+}
+
+// switchCluster connects to the named profile and discards everything tied
+// to the previous cluster: consumers, topic views and the admin client.
+func (m model) switchCluster(name string) (model, tea.Cmd) {
+	if name == m.profile.Name {
+		return m, nil
+	}
+
+	var profile config.Profile
+	for _, p := range m.profiles {
+		if p.Name == name {
+			profile = p
+		}
+	}
+
+	client, err := kafkaadmin.NewClient(clientOptions(profile))
+	if err != nil {
+		return m, m.toastMgr.ShowError(fmt.Sprintf("Failed to connect to %s: %v", name, err))
+	}
+	deserializers, err := profileDeserializers(profile)
+	if err != nil {
+		client.Close()
+		return m, m.toastMgr.ShowError(fmt.Sprintf("Failed to connect to %s: %v", name, err))
+	}
+
+	for topic, cancel := range m.activeConsumers {
+		cancel()
+		delete(m.activeConsumers, topic)
+	}
+	m.topicViewModels = make(map[string]*ui.TopicViewModel)
+	m.topicConfigView = nil
+	m.partitionsView = nil
+	m.groupsView = nil
+	m.groupDetailView = nil
+	m.resetOffsetsView = nil
+	m.brokersView = nil
+	m.brokerDetailView = nil
+	m.brokerConfigView = nil
+	m.diskView = nil
+	m.aclsView = nil
+	m.scramUsersView = nil
+	m.quotasView = nil
+	m.selectedGroup = ""
+	m.consumerCtx, m.consumerCancel, m.messageChan = nil, nil, nil
+	m.selectedTopic = ""
+
+	m.client.Close()
+	m.client = client
+	m.deserializers = deserializers
+	m.profile = profile
+	m.currentView = viewTopicsList
+	m.list.ResetFilter()
+	m.topics = nil
+	m.topicSizes = nil
+	m.refreshTopicList()
+
+	return m, tea.Batch(
+		app.FetchTopicsCmd(m.client),
+		app.FetchTopicSizesCmd(m.client),
+		m.toastMgr.ShowInfo(fmt.Sprintf("Switched to %s", name)),
+	)
+}
+
+func (m model) updateTopicConfig(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ui.BackMsg:
+		m.currentView = viewTopicsList
+		m.topicConfigView = nil
+		return m, nil
+
+	case app.TopicConfigsLoadedMsg:
+		if msg.Err != nil {
+			m.topicConfigView.SetStatus(fmt.Sprintf("Failed to load configs: %v", msg.Err))
+			return m, m.toastMgr.ShowError(fmt.Sprintf("Failed to load configs: %v", msg.Err))
+		}
+		m.topicConfigView.SetEntries(msg.Entries)
+		return m, nil
+
+	case ui.ConfigChangesConfirmedMsg:
+		return m, app.AlterTopicConfigsCmd(m.client, m.selectedTopic, msg.Changes)
+
+	case app.TopicConfigsAlteredMsg:
+		if msg.Err != nil {
+			m.topicConfigView.SetStatus(fmt.Sprintf("Failed to alter configs: %v", msg.Err))
+			return m, m.toastMgr.ShowError(fmt.Sprintf("Failed to alter configs: %v", msg.Err))
+		}
+		return m, tea.Batch(
+			m.toastMgr.ShowSuccess(fmt.Sprintf("Updated configs for %s", msg.TopicName)),
+			app.FetchTopicConfigsCmd(m.client, msg.TopicName),
+		)
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+	}
+
+	updatedModel, cmd := m.topicConfigView.Update(msg)
+	m.topicConfigView = updatedModel.(*ui.ConfigViewModel)
+	return m, cmd
+}
+
+func (m model) updateTopicPartitions(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ui.BackMsg:
+		m.currentView = viewTopicsList
+		m.partitionsView = nil
+		return m, nil
+
+	case ui.RefreshMsg:
+		return m, app.FetchPartitionsCmd(m.client, m.selectedTopic)
+
+	case app.PartitionsLoadedMsg:
+		m.partitionsView.SetPartitions(msg.Partitions, msg.Err)
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+	}
+
+	updatedModel, cmd := m.partitionsView.Update(msg)
+	m.partitionsView = updatedModel.(*ui.PartitionsViewModel)
+	return m, cmd
+}
+
+// tabFor returns the top-level view bound to a number key.
+func tabFor(key string) (viewState, bool) {
+	for _, t := range tabs {
+		if t.key == key {
+			return t.view, true
+		}
+	}
+	return 0, false
+}
+
+// switchTab moves to a top-level view, refreshing its contents.
+func (m model) switchTab(view viewState) (model, tea.Cmd) {
+	if view == m.currentView {
+		return m, nil
+	}
+	m.currentView = view
+
+	switch view {
+	case viewTopicsList:
+		return m, tea.Batch(app.FetchTopicsCmd(m.client), app.FetchTopicSizesCmd(m.client))
+	case viewGroups:
+		if m.groupsView == nil {
+			m.groupsView = ui.NewGroupsViewModel(m.width-10, m.height-10)
+		}
+		return m, app.FetchGroupsCmd(m.client)
+	case viewBrokers:
+		if m.brokersView == nil {
+			m.brokersView = ui.NewBrokersViewModel(m.width-10, m.height-10)
+		}
+		return m, app.FetchBrokersCmd(m.client)
+	case viewDisk:
+		if m.diskView == nil {
+			m.diskView = ui.NewDiskViewModel(m.width-10, m.height-10)
+		}
+		return m, app.FetchDiskUsageCmd(m.client)
+	case viewACLs:
+		if m.aclsView == nil {
+			m.aclsView = ui.NewACLsViewModel(m.width-10, m.height-10)
+		}
+		return m, app.FetchACLsCmd(m.client)
+	case viewSCRAMUsers:
+		if m.scramUsersView == nil {
+			m.scramUsersView = ui.NewSCRAMUsersViewModel(m.width-10, m.height-10)
+		}
+		return m, app.FetchSCRAMUsersCmd(m.client)
+	case viewQuotas:
+		if m.quotasView == nil {
+			m.quotasView = ui.NewQuotasViewModel(m.width-10, m.height-10)
+		}
+		return m, app.FetchQuotasCmd(m.client)
+	}
+	return m, nil
+}
+
+func (m *model) openClusterPicker() tea.Cmd {
+	if len(m.profiles) == 0 {
+		return m.toastMgr.ShowError("No profiles configured to switch to")
+	}
+	items := profileItems(m.profiles)
+	for i := range items {
+		items[i].Active = items[i].Name == m.profile.Name
+	}
+	m.overlayMgr.OpenClusterPicker(items)
+	return nil
+}
+
+// downloadTopicCmd reads the topic with the deserializers picked in its
+// message view, or auto detection if it hasn't been opened.
+func (m model) downloadTopicCmd(client *kafkaadmin.Client, topicName, filePath string) tea.Cmd {
+	key, value := serde.Auto, serde.Auto
+	if vm, exists := m.topicViewModels[topicName]; exists {
+		key, value = vm.Deserializers()
+	}
+	return app.DownloadTopicCmd(client, topicName, filePath, m.deserializers.Get(key), m.deserializers.Get(value))
+}
+
+// resize lays out the topic list and the top-level tabs for a new terminal
+// size. Tabs render inside the panel, so they get its inner dimensions.
+func (m *model) resize(width, height int) {
+	m.width = width
+	m.height = height
+
+	h := height - 10 // Leave room for header, help, padding
+	m.list.SetWidth(width - 8)
+	m.list.SetHeight(h)
+
+	panelSize := tea.WindowSizeMsg{Width: width - 10, Height: h}
+	if m.groupsView != nil {
+		m.groupsView.Update(panelSize)
+	}
+	if m.brokersView != nil {
+		m.brokersView.Update(panelSize)
+	}
+	if m.diskView != nil {
+		m.diskView.Update(panelSize)
+	}
+	if m.aclsView != nil {
+		m.aclsView.Update(panelSize)
+	}
+	if m.scramUsersView != nil {
+		m.scramUsersView.Update(panelSize)
+	}
+	if m.quotasView != nil {
+		m.quotasView.Update(panelSize)
+	}
+}
+
+// updateTab handles what the top-level tabs other than the topic list have
+// in common: overlays, cluster switching, resizing and the global keys.
+// typing reports whether the tab has a text input focused.
+func (m model) updateTab(msg tea.Msg, typing bool) (model, tea.Cmd, bool) {
+	if handled, cmd := m.overlayMgr.Update(msg, m.client, &m.toastMgr, app.FetchTopicsCmd, m.downloadTopicCmd); handled {
+		return m, cmd, true
+	}
+
+	switch msg := msg.(type) {
+	case ui.ProfileSelectedMsg:
+		m, cmd := m.switchCluster(msg.Name)
+		return m, cmd, true
+
+	case tea.WindowSizeMsg:
+		m.resize(msg.Width, msg.Height)
+		return m, nil, true
+
+	case tea.KeyMsg:
+		if typing {
+			break
+		}
+		if view, ok := tabFor(msg.String()); ok {
+			m, cmd := m.switchTab(view)
+			return m, cmd, true
+		}
+		switch msg.String() {
+		case "ctrl+c", "q":
+			return m, tea.Quit, true
+		case "s":
+			return m, m.openClusterPicker(), true
+		}
+	}
+	return m, nil, false
+}
+
+func (m model) updateGroups(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m, cmd, handled := m.updateTab(msg, m.groupsView.Typing())
+	if handled {
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case ui.RefreshMsg:
+		return m, app.FetchGroupsCmd(m.client)
+
+	case ui.GroupsDeleteRequestedMsg:
+		if m.client.ReadOnly() {
+			return m, m.toastMgr.ShowError(kafkaadmin.ErrReadOnly.Error())
+		}
+		m.overlayMgr.OpenDeleteGroups(msg.Groups)
+		return m, nil
+
+	case app.GroupsDeletedMsg:
+		if msg.Err != nil {
+			return m, m.toastMgr.ShowError(fmt.Sprintf("Failed to delete groups: %v", msg.Err))
+		}
+		m.overlayMgr.OpenResults("Delete Consumer Groups", msg.Results)
+		return m, app.FetchGroupsCmd(m.client)
+
+	case ui.GroupSelectedMsg:
+		m.selectedGroup = msg.Name
+		m.currentView = viewGroupDetail
+		m.groupDetailView = ui.NewGroupDetailViewModel(msg.Name, m.width, m.height)
+		return m, app.FetchGroupDetailCmd(m.client, msg.Name)
+	}
+
+	updatedModel, cmd := m.groupsView.Update(msg)
+	m.groupsView = updatedModel.(*ui.GroupsViewModel)
+	return m, cmd
+}
+
+func (m model) updateGroupDetail(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if handled, cmd := m.overlayMgr.Update(msg, m.client, &m.toastMgr, app.FetchTopicsCmd, m.downloadTopicCmd); handled {
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case ui.BackMsg:
+		m.currentView = viewGroups
+		m.groupDetailView = nil
+		return m, app.FetchGroupsCmd(m.client)
+
+	case ui.RefreshMsg:
+		return m, app.FetchGroupDetailCmd(m.client, m.selectedGroup)
+
+	case ui.OffsetsDeleteRequestedMsg:
+		if m.client.ReadOnly() {
+			return m, m.toastMgr.ShowError(kafkaadmin.ErrReadOnly.Error())
+		}
+		m.overlayMgr.OpenDeleteOffsets(msg.Group, msg.Partitions)
+		return m, nil
+
+	case app.OffsetsDeletedMsg:
+		if msg.Err != nil {
+			return m, m.toastMgr.ShowError(fmt.Sprintf("Failed to delete offsets: %v", msg.Err))
+		}
+		m.overlayMgr.OpenResults(fmt.Sprintf("Delete Offsets • %s", msg.Group), msg.Results)
+		return m, app.FetchGroupDetailCmd(m.client, msg.Group)
+
+	case ui.ResetOffsetsRequestedMsg:
+		if m.client.ReadOnly() {
+			return m, m.toastMgr.ShowError(kafkaadmin.ErrReadOnly.Error())
+		}
+		if msg.Members > 0 {
+			return m, m.toastMgr.ShowError(fmt.Sprintf("%s has %d active members; stop its consumers before resetting offsets", msg.Group, msg.Members))
+		}
+		if len(msg.Partitions) == 0 {
+			return m, m.toastMgr.ShowError(fmt.Sprintf("%s has no committed offsets to reset", msg.Group))
+		}
+		m.currentView = viewResetOffsets
+		m.resetOffsetsView = ui.NewResetOffsetsViewModel(msg.Group, msg.Partitions, m.width, m.height)
+		return m, nil
+
+	case app.GroupDetailLoadedMsg:
+		if msg.Name != m.selectedGroup {
+			return m, nil
+		}
+		m.groupDetailView.SetDetail(msg.Detail, msg.Err)
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+	}
+
+	updatedModel, cmd := m.groupDetailView.Update(msg)
+	m.groupDetailView = updatedModel.(*ui.GroupDetailViewModel)
+	return m, cmd
+}
+
+func (m model) openBrokerConfigs(msg ui.BrokerConfigsRequestedMsg) (model, tea.Cmd) {
+	m.configReturnView = m.currentView
+	m.currentView = viewBrokerConfig
+	m.selectedBroker = msg.NodeID
+	title := fmt.Sprintf("broker %d configs", msg.NodeID)
+	if msg.ClusterDefaults {
+		m.selectedBroker = kafkaadmin.AllBrokers
+		title = "cluster-wide broker defaults"
+	}
+	m.brokerConfigView = ui.NewConfigViewModel(title, m.width, m.height)
+	return m, app.FetchBrokerConfigsCmd(m.client, m.selectedBroker)
+}
+
+func (m model) updateBrokerConfig(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ui.BackMsg:
+		m.currentView = m.configReturnView
+		m.brokerConfigView = nil
+		return m, nil
+
+	case app.BrokerConfigsLoadedMsg:
+		if msg.Err != nil {
+			m.brokerConfigView.SetStatus(fmt.Sprintf("Failed to load configs: %v", msg.Err))
+			return m, m.toastMgr.ShowError(fmt.Sprintf("Failed to load configs: %v", msg.Err))
+		}
+		m.brokerConfigView.SetEntries(msg.Entries)
+		return m, nil
+
+	case ui.ConfigChangesConfirmedMsg:
+		return m, app.AlterBrokerConfigsCmd(m.client, m.selectedBroker, msg.Changes)
+
+	case app.BrokerConfigsAlteredMsg:
+		if msg.Err != nil {
+			m.brokerConfigView.SetStatus(fmt.Sprintf("Failed to alter configs: %v", msg.Err))
+			return m, m.toastMgr.ShowError(fmt.Sprintf("Failed to alter configs: %v", msg.Err))
+		}
+		return m, tea.Batch(
+			m.toastMgr.ShowSuccess("Updated broker configs"),
+			app.FetchBrokerConfigsCmd(m.client, msg.Broker),
+		)
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+	}
+
+	updatedModel, cmd := m.brokerConfigView.Update(msg)
+	m.brokerConfigView = updatedModel.(*ui.ConfigViewModel)
+	return m, cmd
+}
+
+func (m model) updateBrokers(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m, cmd, handled := m.updateTab(msg, false)
+	if handled {
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case ui.RefreshMsg:
+		return m, app.FetchBrokersCmd(m.client)
+
+	case ui.BrokerConfigsRequestedMsg:
+		return m.openBrokerConfigs(msg)
+
+	case ui.BrokerSelectedMsg:
+		m.currentView = viewBrokerDetail
+		m.brokerDetailView = ui.NewBrokerDetailViewModel(msg.NodeID, m.width, m.height)
+		return m, app.FetchBrokersCmd(m.client)
+	}
+
+	updatedModel, cmd := m.brokersView.Update(msg)
+	m.brokersView = updatedModel.(*ui.BrokersViewModel)
+	return m, cmd
+}
+
+func (m model) updateDisk(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m, cmd, handled := m.updateTab(msg, false)
+	if handled {
+		return m, cmd
+	}
+
+	switch msg.(type) {
+	case ui.RefreshMsg:
+		return m, app.FetchDiskUsageCmd(m.client)
+	}
+
+	updatedModel, cmd := m.diskView.Update(msg)
+	m.diskView = updatedModel.(*ui.DiskViewModel)
+	return m, cmd
+}
+
+func (m model) updateACLs(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m, cmd, handled := m.updateTab(msg, m.aclsView.Typing())
+	if handled {
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case ui.RefreshMsg:
+		return m, app.FetchACLsCmd(m.client)
+
+	case ui.ACLCreateRequestedMsg:
+		if m.client.ReadOnly() {
+			return m, m.toastMgr.ShowError(kafkaadmin.ErrReadOnly.Error())
+		}
+		m.overlayMgr.OpenCreateACL()
+		return m, nil
+
+	case ui.ACLDeleteRequestedMsg:
+		if m.client.ReadOnly() {
+			return m, m.toastMgr.ShowError(kafkaadmin.ErrReadOnly.Error())
+		}
+		m.overlayMgr.OpenDeleteACLFilter(msg.Filter)
+		return m, nil
+
+	case app.ACLsCreatedMsg:
+		if msg.Err != nil {
+			return m, m.toastMgr.ShowError(fmt.Sprintf("Failed to create ACLs: %v", msg.Err))
+		}
+		m.overlayMgr.OpenResults("Create ACLs", msg.Results)
+		return m, app.FetchACLsCmd(m.client)
+
+	case app.ACLDeletePreviewMsg:
+		if msg.Err != nil {
+			return m, m.toastMgr.ShowError(fmt.Sprintf("Failed to match ACLs: %v", msg.Err))
+		}
+		if len(msg.Matches) == 0 {
+			return m, m.toastMgr.ShowInfo("No ACLs match that filter")
+		}
+		m.overlayMgr.OpenDeleteACLs(msg.Filter, msg.Matches)
+		return m, nil
+
+	case app.ACLsDeletedMsg:
+		if msg.Err != nil {
+			return m, m.toastMgr.ShowError(fmt.Sprintf("Failed to delete ACLs: %v", msg.Err))
+		}
+		m.overlayMgr.OpenResults("Delete ACLs", msg.Results)
+		return m, app.FetchACLsCmd(m.client)
+	}
+
+	updatedModel, cmd := m.aclsView.Update(msg)
+	m.aclsView = updatedModel.(*ui.ACLsViewModel)
+	return m, cmd
+}
+
+func (m model) updateSCRAMUsers(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m, cmd, handled := m.updateTab(msg, m.scramUsersView.Typing())
+	if handled {
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case ui.RefreshMsg:
+		return m, app.FetchSCRAMUsersCmd(m.client)
+
+	case ui.SCRAMUserRequestedMsg:
+		if m.client.ReadOnly() {
+			return m, m.toastMgr.ShowError(kafkaadmin.ErrReadOnly.Error())
+		}
+		m.overlayMgr.OpenSCRAMUser(msg.User, msg.Mechanism, msg.Iterations)
+		return m, nil
+
+	case ui.SCRAMDeleteRequestedMsg:
+		if m.client.ReadOnly() {
+			return m, m.toastMgr.ShowError(kafkaadmin.ErrReadOnly.Error())
+		}
+		m.overlayMgr.OpenDeleteSCRAM(msg.User, msg.Mechanism)
+		return m, nil
+
+	case app.SCRAMUserAlteredMsg:
+		if msg.Err != nil {
+			return m, m.toastMgr.ShowError(fmt.Sprintf("Failed to update %s: %v", msg.User, msg.Err))
+		}
+		done := fmt.Sprintf("Password set for %s", msg.User)
+		if msg.Deleted {
+			done = fmt.Sprintf("Credential deleted for %s", msg.User)
+		}
+		return m, tea.Batch(m.toastMgr.ShowSuccess(done), app.FetchSCRAMUsersCmd(m.client))
+	}
+
+	updatedModel, cmd := m.scramUsersView.Update(msg)
+	m.scramUsersView = updatedModel.(*ui.SCRAMUsersViewModel)
+	return m, cmd
+}
+
+func (m model) updateQuotas(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m, cmd, handled := m.updateTab(msg, m.quotasView.Typing())
+	if handled {
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case ui.RefreshMsg:
+		return m, app.FetchQuotasCmd(m.client)
+
+	case ui.QuotaEditRequestedMsg:
+		if m.client.ReadOnly() {
+			return m, m.toastMgr.ShowError(kafkaadmin.ErrReadOnly.Error())
+		}
+		m.overlayMgr.OpenQuota(msg.Quota)
+		return m, nil
+
+	case ui.QuotaDeleteRequestedMsg:
+		if m.client.ReadOnly() {
+			return m, m.toastMgr.ShowError(kafkaadmin.ErrReadOnly.Error())
+		}
+		m.overlayMgr.OpenDeleteQuota(msg.Quota)
+		return m, nil
+
+	case app.QuotaAlteredMsg:
+		if msg.Err != nil {
+			return m, m.toastMgr.ShowError(fmt.Sprintf("Failed to update quotas: %v", msg.Err))
+		}
+		return m, tea.Batch(
+			m.toastMgr.ShowSuccess(fmt.Sprintf("Updated quotas for %s", msg.Entity)),
+			app.FetchQuotasCmd(m.client),
+		)
+	}
+
+	updatedModel, cmd := m.quotasView.Update(msg)
+	m.quotasView = updatedModel.(*ui.QuotasViewModel)
+	return m, cmd
+}
+
+func (m model) updateBrokerDetail(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ui.BackMsg:
+		m.currentView = viewBrokers
+		m.brokerDetailView = nil
+		return m, nil
+
+	case ui.RefreshMsg:
+		return m, app.FetchBrokersCmd(m.client)
+
+	case ui.BrokerConfigsRequestedMsg:
+		return m.openBrokerConfigs(msg)
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+	}
+
+	updatedModel, cmd := m.brokerDetailView.Update(msg)
+	m.brokerDetailView = updatedModel.(*ui.BrokerDetailViewModel)
+	return m, cmd
+}
+
+func (m model) updateResetOffsets(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ui.BackMsg:
+		m.currentView = viewGroupDetail
+		m.resetOffsetsView = nil
+		return m, nil
+
+	case ui.OffsetResetPreviewMsg:
+		return m, app.PlanOffsetResetCmd(m.client, msg.Request)
+
+	case app.OffsetResetPlannedMsg:
+		m.resetOffsetsView.SetPreview(msg.Rows, msg.Err)
+		return m, nil
+
+	case ui.OffsetResetConfirmedMsg:
+		return m, app.ResetOffsetsCmd(m.client, msg.Group, msg.Rows)
+
+	case app.OffsetsResetMsg:
+		if msg.Err != nil {
+			m.resetOffsetsView.SetApplyError(msg.Err)
+			return m, m.toastMgr.ShowError(fmt.Sprintf("Failed to reset offsets: %v", msg.Err))
+		}
+		m.currentView = viewGroupDetail
+		m.resetOffsetsView = nil
+		return m, tea.Batch(
+			m.toastMgr.ShowSuccess(fmt.Sprintf("Reset %d offsets for %s", msg.Count, msg.Group)),
+			app.FetchGroupDetailCmd(m.client, msg.Group),
+		)
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+	}
+
+	updatedModel, cmd := m.resetOffsetsView.Update(msg)
+	m.resetOffsetsView = updatedModel.(*ui.ResetOffsetsViewModel)
+	return m, cmd
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -73,19 +794,161 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// Records are decoded in the background, so a batch may land after the
+	// user has left the topic.
+	if decoded, ok := msg.(ui.RecordsDecodedMsg); ok {
+		if vm, exists := m.topicViewModels[decoded.Topic]; exists {
+			vm.SetDecoded(decoded)
+		}
+		return m, nil
+	}
+
+	// A produce can finish after its form was closed or the view changed.
+	if produced, ok := msg.(app.MessageProducedMsg); ok {
+		return m, m.overlayMgr.ProduceDone(produced, &m.toastMgr)
+	}
+
+	if m.currentView == viewTopicConfig {
+		return m.updateTopicConfig(msg)
+	}
+
+	if m.currentView == viewTopicPartitions {
+		return m.updateTopicPartitions(msg)
+	}
+
+	// Group listings are fetched in the background, so one may land after
+	// the user has already moved to another view.
+	if loaded, ok := msg.(app.GroupsLoadedMsg); ok {
+		if loaded.Client == m.client && m.groupsView != nil {
+			m.groupsView.SetGroups(loaded.Groups, loaded.Err)
+		}
+		return m, nil
+	}
+
+	// The brokers tab and broker detail share one metadata fetch.
+	if loaded, ok := msg.(app.BrokersLoadedMsg); ok {
+		if loaded.Client != m.client {
+			return m, nil
+		}
+		if m.brokersView != nil {
+			m.brokersView.SetCluster(loaded.Cluster, loaded.Err)
+		}
+		if m.brokerDetailView != nil {
+			m.brokerDetailView.SetCluster(loaded.Cluster, loaded.Err)
+		}
+		return m, nil
+	}
+
+	if loaded, ok := msg.(app.DiskUsageLoadedMsg); ok {
+		if loaded.Client == m.client && m.diskView != nil {
+			m.diskView.SetUsage(loaded.Usage, loaded.Err)
+		}
+		return m, nil
+	}
+
+	if loaded, ok := msg.(app.ACLsLoadedMsg); ok {
+		if loaded.Client == m.client && m.aclsView != nil {
+			m.aclsView.SetACLs(loaded.ACLs, loaded.Err)
+		}
+		return m, nil
+	}
+
+	if loaded, ok := msg.(app.SCRAMUsersLoadedMsg); ok {
+		if loaded.Client == m.client && m.scramUsersView != nil {
+			m.scramUsersView.SetCredentials(loaded.Credentials, loaded.Err)
+		}
+		return m, nil
+	}
+
+	if loaded, ok := msg.(app.QuotasLoadedMsg); ok {
+		if loaded.Client == m.client && m.quotasView != nil {
+			m.quotasView.SetQuotas(loaded.Quotas, loaded.Err)
+		}
+		return m, nil
+	}
+
+	if m.currentView == viewGroups {
+		return m.updateGroups(msg)
+	}
+
+	if m.currentView == viewGroupDetail {
+		return m.updateGroupDetail(msg)
+	}
+
+	if m.currentView == viewResetOffsets {
+		return m.updateResetOffsets(msg)
+	}
+
+	if m.currentView == viewBrokers {
+		return m.updateBrokers(msg)
+	}
+
+	if m.currentView == viewBrokerDetail {
+		return m.updateBrokerDetail(msg)
+	}
+
+	if m.currentView == viewBrokerConfig {
+		return m.updateBrokerConfig(msg)
+	}
+
+	if m.currentView == viewDisk {
+		return m.updateDisk(msg)
+	}
+
+	if m.currentView == viewACLs {
+		return m.updateACLs(msg)
+	}
+
+	if m.currentView == viewSCRAMUsers {
+		return m.updateSCRAMUsers(msg)
+	}
+
+	if m.currentView == viewQuotas {
+		return m.updateQuotas(msg)
+	}
+
 	if m.currentView == viewTopicDetail {
 
 		if kafkaMsg, ok := msg.(app.KafkaMessageReceivedMsg); ok {
+			// A batch may still be in flight from the consumer a new start
+			// position or partition filter just replaced.
+			if kafkaMsg.Chan != m.messageChan {
+				return m, nil
+			}
 			if len(kafkaMsg.Records) > 0 {
+				wait := app.WaitForMessageCmd(m.consumerCtx, m.messageChan)
 				if vm, exists := m.topicViewModels[m.selectedTopic]; exists {
-					vm.AddMessages(kafkaMsg.Records)
+					return m, tea.Batch(vm.AddMessages(kafkaMsg.Records), wait)
 				}
-				return m, app.WaitForMessageCmd(m.consumerCtx, m.messageChan)
+				return m, wait
 			}
 		}
 
+		if requested, ok := msg.(ui.ConsumeRequestedMsg); ok {
+			if cancel, exists := m.activeConsumers[requested.Topic]; exists {
+				cancel()
+			}
+			m.consumerCtx, m.consumerCancel = context.WithCancel(context.Background())
+			m.messageChan = make(chan *kgo.Record, 10000)
+			m.activeConsumers[requested.Topic] = m.consumerCancel
+			return m, app.StartConsumerCmd(m.client, m.consumerCtx, requested.Topic, requested.Start, requested.Partitions, m.messageChan)
+		}
+
+		if failed, ok := msg.(app.ConsumerFailedMsg); ok {
+			return m, m.toastMgr.ShowError(fmt.Sprintf("Failed to read %s: %v", failed.Topic, failed.Err))
+		}
+
+		// Leaving the start position picker before anything was read goes
+		// back to the topics, and opening the topic again asks again.
+		if _, ok := msg.(ui.BackMsg); ok {
+			delete(m.topicViewModels, m.selectedTopic)
+			m.currentView = viewTopicsList
+			return m, nil
+		}
+
 		if keyMsg, ok := msg.(tea.KeyMsg); ok {
-			if keyMsg.String() == "esc" {
+			vm, exists := m.topicViewModels[m.selectedTopic]
+			if keyMsg.String() == "esc" && (!exists || !vm.Typing()) {
 				m.currentView = viewTopicsList
 				return m, nil
 			}
@@ -104,14 +967,31 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	if handled, cmd := m.overlayMgr.Update(msg, m.client, &m.toastMgr, app.FetchTopicsCmd, app.DownloadTopicCmd); handled {
+	if handled, cmd := m.overlayMgr.Update(msg, m.client, &m.toastMgr, app.FetchTopicsCmd, m.downloadTopicCmd); handled {
 		return m, cmd
 	}
 
 	switch msg := msg.(type) {
 
+	case ui.ProfileSelectedMsg:
+		return m.switchCluster(msg.Name)
+
 	case app.TopicsLoadedMsg:
-		m.list.SetItems(msg.Items)
+		if msg.Client != m.client {
+			return m, nil // stale result from a cluster we switched away from
+		}
+		m.topics = msg.Topics
+		app.WithSizes(m.topics, m.topicSizes)
+		m.refreshTopicList()
+		return m, nil
+
+	case app.TopicSizesLoadedMsg:
+		if msg.Client != m.client {
+			return m, nil
+		}
+		m.topicSizes = msg.Sizes
+		app.WithSizes(m.topics, m.topicSizes)
+		m.refreshTopicList()
 		return m, nil
 
 	case app.DownloadCompleteMsg:
@@ -122,29 +1002,51 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case tea.WindowSizeMsg:
-		m.width = msg.Width
-		m.height = msg.Height
-
-		h := msg.Height - 10 // Leave room for header, help, padding
-		m.list.SetWidth(msg.Width - 8)
-		m.list.SetHeight(h)
+		m.resize(msg.Width, msg.Height)
 		return m, nil
 
 	case tea.KeyMsg:
+		if m.list.FilterState() == list.Filtering {
+			break // let the filter input have every key
+		}
+
+		if view, ok := tabFor(msg.String()); ok {
+			return m.switchTab(view)
+		}
+
 		switch msg.String() {
 		case "ctrl+c":
 			return m, tea.Quit
 
+		case "o": // cycle sort column
+			m.topicSort = m.topicSort.Next()
+			m.refreshTopicList()
+			return m, nil
+
+		case "i": // toggle internal topics
+			m.showInternal = !m.showInternal
+			m.refreshTopicList()
+			return m, nil
+
+		case "s": // switch cluster
+			return m, m.openClusterPicker()
+
 		case "c": // create topic
+			if m.client.ReadOnly() {
+				return m, m.toastMgr.ShowError(kafkaadmin.ErrReadOnly.Error())
+			}
 			m.overlayMgr.OpenCreateTopic()
 			return m, nil
 
 		case "p": // produce message
+			if m.client.ReadOnly() {
+				return m, m.toastMgr.ShowError(kafkaadmin.ErrReadOnly.Error())
+			}
 			selectedItem := m.list.SelectedItem()
 			if selectedItem != nil {
 				topic := selectedItem.(app.TopicItem)
 				m.selectedTopic = topic.Name
-				m.overlayMgr.OpenProduceMessage(topic.Name)
+				m.overlayMgr.OpenProduceMessage(topic.Name, m.profile.KeySerde, m.profile.ValueSerde, m.deserializers)
 				return m, nil
 			}
 
@@ -157,7 +1059,42 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 
+		case "C": // topic configs
+			selectedItem := m.list.SelectedItem()
+			if selectedItem != nil {
+				topic := selectedItem.(app.TopicItem)
+				m.selectedTopic = topic.Name
+				m.currentView = viewTopicConfig
+				m.topicConfigView = ui.NewConfigViewModel(fmt.Sprintf("%s configs", topic.Name), m.width, m.height)
+				return m, app.FetchTopicConfigsCmd(m.client, topic.Name)
+			}
+
+		case "P": // partitions
+			selectedItem := m.list.SelectedItem()
+			if selectedItem != nil {
+				topic := selectedItem.(app.TopicItem)
+				m.selectedTopic = topic.Name
+				m.currentView = viewTopicPartitions
+				m.partitionsView = ui.NewPartitionsViewModel(topic.Name, m.width, m.height)
+				return m, app.FetchPartitionsCmd(m.client, topic.Name)
+			}
+
+		case "+": // add partitions
+			if m.client.ReadOnly() {
+				return m, m.toastMgr.ShowError(kafkaadmin.ErrReadOnly.Error())
+			}
+			selectedItem := m.list.SelectedItem()
+			if selectedItem != nil {
+				topic := selectedItem.(app.TopicItem)
+				m.selectedTopic = topic.Name
+				m.overlayMgr.OpenAddPartitions(topic.Name, topic.Partitions)
+				return m, nil
+			}
+
 		case "x", "X": // delete topic
+			if m.client.ReadOnly() {
+				return m, m.toastMgr.ShowError(kafkaadmin.ErrReadOnly.Error())
+			}
 			selectedItem := m.list.SelectedItem()
 			if selectedItem != nil {
 				topic := selectedItem.(app.TopicItem)
@@ -173,17 +1110,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.selectedTopic = topic.Name
 				m.currentView = viewTopicDetail
 
+				// The consumer starts once the view's picker has a start
+				// position.
 				if _, exists := m.topicViewModels[topic.Name]; !exists {
-					m.topicViewModels[topic.Name] = ui.NewTopicViewModel(topic.Name, m.width, m.height)
-				}
-
-				if _, exists := m.activeConsumers[topic.Name]; !exists {
-					m.consumerCtx, m.consumerCancel = context.WithCancel(context.Background())
-					m.messageChan = make(chan *kgo.Record, 10000)
-
-					m.activeConsumers[topic.Name] = m.consumerCancel
-
-					return m, app.StartConsumerCmd(m.client, m.consumerCtx, topic.Name, m.messageChan)
+					m.topicViewModels[topic.Name] = ui.NewTopicViewModel(topic.Name, m.deserializers, m.width, m.height)
 				}
 
 				return m, nil
@@ -207,19 +1137,69 @@ func (m model) View() string {
 		return m.toastMgr.Wrap("Error: Topic view model not found")
 	}
 
+	if m.currentView == viewTopicConfig {
+		return m.toastMgr.Wrap(m.topicConfigView.View())
+	}
+
+	if m.currentView == viewTopicPartitions {
+		return m.toastMgr.Wrap(m.partitionsView.View())
+	}
+
+	if m.currentView == viewGroupDetail {
+		return m.toastMgr.Wrap(m.overlayMgr.View(m.groupDetailView.View()))
+	}
+
+	if m.currentView == viewResetOffsets {
+		return m.toastMgr.Wrap(m.resetOffsetsView.View())
+	}
+
+	if m.currentView == viewBrokerDetail {
+		return m.toastMgr.Wrap(m.brokerDetailView.View())
+	}
+
+	if m.currentView == viewBrokerConfig {
+		return m.toastMgr.Wrap(m.brokerConfigView.View())
+	}
+
+	target := fmt.Sprintf("→ %s", m.profile.Name)
+	if m.client.ReadOnly() {
+		target += " [read-only]"
+	}
 	header := ui.HeaderStyle.Width(m.width - 4).Render(
-		fmt.Sprintf("%s %s",
+		fmt.Sprintf("%s %s  %s",
 			ui.TitleStyle.Render("lazykafka"),
-			fmt.Sprintf("→ %s", m.bootstrapServers),
+			target,
+			m.renderTabs(),
 		),
 	)
 
+	panelContent := m.list.View()
+	help := ui.HelpStyle.Render("↑/↓ j/k: navigate • /: filter • o: sort • i: internal topics • s: switch cluster • c: create topic • C: configs • P: partitions • +: add partitions • x: delete topic • p: produce message • d: download topic • q: quit")
+	switch m.currentView {
+	case viewGroups:
+		panelContent = m.groupsView.View()
+		help = ui.HelpStyle.Render("↑/↓ j/k: navigate • /: filter • enter: details • space: mark • x: delete groups • r: refresh • s: switch cluster • q: quit")
+	case viewDisk:
+		panelContent = m.diskView.View()
+		help = ui.HelpStyle.Render("↑/↓ j/k: navigate • g: group by broker/dir/topic/partition • o: sort by size/name • r: refresh • s: switch cluster • q: quit")
+	case viewACLs:
+		panelContent = m.aclsView.View()
+		help = ui.HelpStyle.Render("↑/↓ j/k: navigate • /: filter • c: create ACL • x: delete ACLs • r: refresh • s: switch cluster • q: quit")
+	case viewSCRAMUsers:
+		panelContent = m.scramUsersView.View()
+		help = ui.HelpStyle.Render("↑/↓ j/k: navigate • /: filter • c: create user • p: change password • x: delete credential • r: refresh • s: switch cluster • q: quit")
+	case viewQuotas:
+		panelContent = m.quotasView.View()
+		help = ui.HelpStyle.Render("↑/↓ j/k: navigate • /: filter • c: add quota • e/enter: edit • x: remove quotas • r: refresh • s: switch cluster • q: quit")
+	case viewBrokers:
+		panelContent = m.brokersView.View()
+		help = ui.HelpStyle.Render("↑/↓ j/k: navigate • enter: details • C: broker configs • D: cluster-wide defaults • r: refresh • s: switch cluster • q: quit")
+	}
+
 	listPanel := ui.PanelStyle.
 		Width(m.width - 8).
 		Height(m.height - 8).
-		Render(m.list.View())
-
-	help := ui.HelpStyle.Render("↑/↓ j/k: navigate • /: filter • c: create topic • x: delete topic • p: produce message • d: download topic • q: quit")
+		Render(panelContent)
 
 	content := lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -233,28 +1213,269 @@ func (m model) View() string {
 	return m.toastMgr.Wrap(m.overlayMgr.View(background))
 }
 
+func (m model) renderTabs() string {
+	names := make([]string, len(tabs))
+	for i, t := range tabs {
+		label := fmt.Sprintf("%s %s", t.key, t.name)
+		if t.view == m.currentView {
+			names[i] = ui.TabActiveStyle.Render(label)
+		} else {
+			names[i] = ui.TabStyle.Render(label)
+		}
+	}
+	return strings.Join(names, " ")
+}
+
+const usage = `Usage: lazykafka [--config path] [--profile name | bootstrap-servers]
+
+Connects to the named profile from the config file, or directly to a comma
+separated list of bootstrap servers. With neither, the config's
+default_profile is used, or a picker is shown when there are several.
+
+Flags:
+`
+
+func parseArgs(argv []string) (structs.Args, error) {
+	var args structs.Args
+
+	fs := flag.NewFlagSet("lazykafka", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), usage)
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&args.ConfigPath, "config", "", "path to the config file (default ~/.config/lazykafka/config.yaml)")
+	fs.StringVar(&args.Profile, "profile", "", "name of the cluster profile to connect to")
+
+	if err := fs.Parse(argv); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			fs.SetOutput(os.Stderr)
+			fs.Usage()
+		}
+		return args, err
+	}
+
+	switch fs.NArg() {
+	case 0:
+	case 1:
+		args.Bootstrap = fs.Arg(0)
+	default:
+		return args, fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args()[1:], " "))
+	}
+
+	if args.Bootstrap != "" && args.Profile != "" {
+		return args, errors.New("--profile and a bootstrap server argument are mutually exclusive")
+	}
+	return args, nil
+}
+
+// resolveProfile picks the profile to connect to and returns it along with
+// every configured profile, so the cluster switcher can offer them later.
+func resolveProfile(args structs.Args) (config.Profile, []config.Profile, error) {
+	path := args.ConfigPath
+	if path == "" {
+		defaultPath, err := config.DefaultPath()
+		if err != nil {
+			return config.Profile{}, nil, err
+		}
+		path = defaultPath
+	}
+
+	cfg, err := config.Load(path)
+	if args.Bootstrap != "" {
+		if err != nil && !errors.Is(err, config.ErrNoConfig) {
+			return config.Profile{}, nil, err
+		}
+		var profiles []config.Profile
+		if cfg != nil {
+			profiles = cfg.Profiles
+		}
+		return config.AdHocProfile(args.Bootstrap), profiles, nil
+	}
+	if err != nil {
+		if errors.Is(err, config.ErrNoConfig) && args.Profile == "" {
+			return config.Profile{}, nil, fmt.Errorf("%w; pass bootstrap servers or create one", err)
+		}
+		return config.Profile{}, nil, err
+	}
+
+	name := args.Profile
+	if name == "" {
+		name = cfg.DefaultProfile
+	}
+	if name == "" {
+		switch len(cfg.Profiles) {
+		case 0:
+			return config.Profile{}, nil, fmt.Errorf("%s defines no profiles", cfg.Path)
+		case 1:
+			name = cfg.Profiles[0].Name
+		default:
+			name, err = pickProfile(cfg.Profiles)
+			if err != nil {
+				return config.Profile{}, nil, err
+			}
+		}
+	}
+
+	profile, err := cfg.Profile(name)
+	return profile, cfg.Profiles, err
+}
+
+func profileItems(profiles []config.Profile) []ui.ProfileItem {
+	items := make([]ui.ProfileItem, len(profiles))
+	for i, p := range profiles {
+		items[i] = ui.ProfileItem{Name: p.Name, Brokers: p.Brokers}
+	}
+	return items
+}
+
+// pickerModel runs the profile picker on its own before the main program
+// starts, since there is no client to build the main model with yet.
+type pickerModel struct {
+	picker   ui.ProfilePicker
+	selected string
+	width    int
+	height   int
+}
+
+func (m pickerModel) Init() tea.Cmd { return nil }
+
+func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ui.ProfileSelectedMsg:
+		m.selected = msg.Name
+		return m, tea.Quit
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc":
+			if !m.picker.Filtering() {
+				return m, tea.Quit
+			}
+		}
+	}
+
+	updated, cmd := m.picker.Update(msg)
+	m.picker = updated.(ui.ProfilePicker)
+	return m, cmd
+}
+
+func (m pickerModel) View() string {
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, m.picker.View())
+}
+
+func pickProfile(profiles []config.Profile) (string, error) {
+	picker := pickerModel{picker: ui.NewProfilePicker("Select a cluster", profileItems(profiles))}
+	result, err := tea.NewProgram(picker, tea.WithAltScreen()).Run()
+	if err != nil {
+		return "", err
+	}
+	selected := result.(pickerModel).selected
+	if selected == "" {
+		return "", errors.New("no profile selected")
+	}
+	return selected, nil
+}
+
+func clientOptions(profile config.Profile) kafkaadmin.Options {
+	return kafkaadmin.Options{
+		Brokers:  profile.Brokers,
+		ClientID: profile.ClientID,
+		TLS: kafkaadmin.TLSOptions{
+			Enabled:            profile.TLS.Enabled,
+			CAFile:             profile.TLS.CAFile,
+			CertFile:           profile.TLS.CertFile,
+			KeyFile:            profile.TLS.KeyFile,
+			InsecureSkipVerify: profile.TLS.InsecureSkipVerify,
+		},
+		SASL: kafkaadmin.SASLOptions{
+			Mechanism: profile.SASL.Mechanism,
+			Username:  profile.SASL.Username,
+			Password:  profile.SASL.Password,
+			Token:     profile.SASL.Token,
+		},
+		ReadOnly: profile.ReadOnly,
+	}
+}
+
+// profileDeserializers returns the built-in deserializers, plus Avro and
+// protobuf when the profile points at a schema registry, and protobuf when
+// it maps topics to local message types.
+func profileDeserializers(profile config.Profile) (*serde.Registry, error) {
+	deserializers := serde.NewRegistry()
+
+	var schemas *serde.SchemaRegistry
+	if profile.SchemaRegistry.URL != "" {
+		var err error
+		schemas, err = serde.NewSchemaRegistry(serde.SchemaRegistryOptions{
+			URL:      profile.SchemaRegistry.URL,
+			Username: profile.SchemaRegistry.Username,
+			Password: profile.SchemaRegistry.Password,
+		})
+		if err != nil {
+			return nil, err
+		}
+		deserializers.Register(serde.NewAvro(schemas))
+	}
+
+	if schemas != nil || len(profile.Protobuf.Topics) > 0 {
+		protobuf, err := serde.NewProtobuf(schemas, serde.ProtobufOptions{
+			ImportPaths:    profile.Protobuf.ImportPaths,
+			Files:          profile.Protobuf.Files,
+			DescriptorSets: profile.Protobuf.DescriptorSets,
+			Topics:         profile.Protobuf.Topics,
+		})
+		if err != nil {
+			return nil, err
+		}
+		deserializers.Register(protobuf)
+	}
+	return deserializers, nil
+}
+
+func fatal(err error) {
+	fmt.Fprintf(os.Stderr, "lazykafka: %v\n", err)
+	os.Exit(1)
+}
+
 func main() {
-	if len(os.Args) < 2 {
-		log.Error("Must provide bootstrapservers")
-		os.Exit(1)
+	args, err := parseArgs(os.Args[1:])
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		fmt.Fprintf(os.Stderr, "lazykafka: %v\nRun 'lazykafka -h' for usage.\n", err)
+		os.Exit(2)
 	}
 
-	bootstrapServers := os.Args[1]
+	profile, profiles, err := resolveProfile(args)
+	if err != nil {
+		fatal(err)
+	}
 
-	adminClient, err := kafkaadmin.NewClient(bootstrapServers)
+	adminClient, err := kafkaadmin.NewClient(clientOptions(profile))
 	if err != nil {
-		log.Errorf("Failed to create admin client: %v", err)
-		os.Exit(1)
+		fatal(fmt.Errorf("failed to create admin client for profile %q: %w", profile.Name, err))
+	}
+	deserializers, err := profileDeserializers(profile)
+	if err != nil {
+		fatal(fmt.Errorf("profile %q: %w", profile.Name, err))
 	}
-	defer adminClient.Close()
 
 	p := tea.NewProgram(
-		initialModel(bootstrapServers, adminClient),
+		initialModel(profile, profiles, adminClient, deserializers),
 		tea.WithAltScreen(),
 		tea.WithMouseCellMotion(),
 	)
-	if _, err := p.Run(); err != nil {
+	finalModel, err := p.Run()
+	if err != nil {
 		fmt.Printf("Alas, there's been an error: %v", err)
 		os.Exit(1)
 	}
+	// The cluster switcher may have replaced the client we started with.
+	finalModel.(model).client.Close()
 }
@@ -0,0 +1,246 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	kafkaadmin "mojosoftware.dev/lazykafka/internal/kafka_admin"
+	"mojosoftware.dev/lazykafka/internal/ui"
+)
+
+type ACLsLoadedMsg struct {
+	Client *kafkaadmin.Client
+	ACLs   []ui.ACLBinding
+	Err    error
+}
+
+type ACLsCreatedMsg struct {
+	Results []ui.ItemResult
+	Err     error
+}
+
+// ACLDeletePreviewMsg carries the bindings a delete filter currently
+// matches, so they can be confirmed before anything is deleted.
+type ACLDeletePreviewMsg struct {
+	Filter  ui.ACLFilter
+	Matches []ui.ACLBinding
+	Err     error
+}
+
+type ACLsDeletedMsg struct {
+	Results []ui.ItemResult
+	Err     error
+}
+
+func FetchACLsCmd(client *kafkaadmin.Client) tea.Cmd {
+	return func() tea.Msg {
+		acls, err := describeACLs(client, ui.ACLFilter{})
+		return ACLsLoadedMsg{Client: client, ACLs: acls, Err: err}
+	}
+}
+
+func CreateACLsCmd(client *kafkaadmin.Client, filter ui.ACLFilter) tea.Cmd {
+	return func() tea.Msg {
+		b, err := aclBuilder(filter, true)
+		if err != nil {
+			return ACLsCreatedMsg{Err: err}
+		}
+		created, err := client.CreateACLs(context.Background(), b)
+		if err != nil {
+			return ACLsCreatedMsg{Err: err}
+		}
+
+		results := make([]ui.ItemResult, len(created))
+		for i, c := range created {
+			binding := aclBinding(c.Principal, c.Host, c.Type, c.Name, c.Pattern, c.Operation, c.Permission)
+			results[i] = ui.ItemResult{Name: binding.String(), Err: c.Err}
+		}
+		return ACLsCreatedMsg{Results: results}
+	}
+}
+
+func PreviewDeleteACLsCmd(client *kafkaadmin.Client, filter ui.ACLFilter) tea.Cmd {
+	return func() tea.Msg {
+		matches, err := describeACLs(client, filter)
+		return ACLDeletePreviewMsg{Filter: filter, Matches: matches, Err: err}
+	}
+}
+
+// DeleteACLsCmd deletes with the same filter that was previewed. Bindings
+// created in between that also match are deleted too, and are listed in the
+// results.
+func DeleteACLsCmd(client *kafkaadmin.Client, filter ui.ACLFilter) tea.Cmd {
+	return func() tea.Msg {
+		b, err := aclBuilder(filter, false)
+		if err != nil {
+			return ACLsDeletedMsg{Err: err}
+		}
+		deleted, err := client.DeleteACLs(context.Background(), b)
+		if err != nil {
+			return ACLsDeletedMsg{Err: err}
+		}
+
+		var results []ui.ItemResult
+		for _, r := range deleted {
+			if r.Err != nil {
+				results = append(results, ui.ItemResult{Name: "filter", Err: r.Err})
+				continue
+			}
+			for _, d := range r.Deleted {
+				binding := aclBinding(d.Principal, d.Host, d.Type, d.Name, d.Pattern, d.Operation, d.Permission)
+				results = append(results, ui.ItemResult{Name: binding.String(), Err: d.Err})
+			}
+		}
+		return ACLsDeletedMsg{Results: results}
+	}
+}
+
+func describeACLs(client *kafkaadmin.Client, filter ui.ACLFilter) ([]ui.ACLBinding, error) {
+	b, err := aclBuilder(filter, false)
+	if err != nil {
+		return nil, err
+	}
+	described, err := client.DescribeACLs(context.Background(), b)
+	if err != nil {
+		return nil, err
+	}
+
+	acls := make([]ui.ACLBinding, len(described))
+	for i, d := range described {
+		acls[i] = aclBinding(d.Principal, d.Host, d.Type, d.Name, d.Pattern, d.Operation, d.Permission)
+	}
+	sort.Slice(acls, func(i, j int) bool {
+		a, b := acls[i], acls[j]
+		if a.ResourceType != b.ResourceType {
+			return a.ResourceType < b.ResourceType
+		}
+		if a.ResourceName != b.ResourceName {
+			return a.ResourceName < b.ResourceName
+		}
+		if a.Principal != b.Principal {
+			return a.Principal < b.Principal
+		}
+		return a.Operation < b.Operation
+	})
+	return acls, nil
+}
+
+func aclBinding(principal, host string, resourceType kmsg.ACLResourceType, name string,
+	pattern kadm.ACLPattern, op kadm.ACLOperation, permission kmsg.ACLPermissionType,
+) ui.ACLBinding {
+	return ui.ACLBinding{
+		Principal:    principal,
+		Host:         host,
+		ResourceType: resourceType.String(),
+		ResourceName: name,
+		Pattern:      pattern.String(),
+		Operation:    op.String(),
+		Permission:   permission.String(),
+	}
+}
+
+// aclBuilder turns the form's fields into a builder. For filters (describe
+// and delete) an empty field matches anything; for creating, the pattern
+// defaults to literal and the host to any host.
+func aclBuilder(f ui.ACLFilter, create bool) (*kadm.ACLBuilder, error) {
+	b := kadm.NewACLs()
+
+	var names []string
+	if f.ResourceName != "" {
+		names = []string{f.ResourceName}
+	}
+	resourceType := kmsg.ACLResourceTypeAny
+	if f.ResourceType != "" {
+		t, err := kmsg.ParseACLResourceType(f.ResourceType)
+		if err != nil {
+			return nil, fmt.Errorf("unknown resource type %q", f.ResourceType)
+		}
+		resourceType = t
+	}
+	switch resourceType {
+	case kmsg.ACLResourceTypeAny:
+		if create {
+			return nil, fmt.Errorf("a resource type is required")
+		}
+		b.AnyResource(names...)
+	case kmsg.ACLResourceTypeTopic:
+		b.Topics(names...)
+	case kmsg.ACLResourceTypeGroup:
+		b.Groups(names...)
+	case kmsg.ACLResourceTypeCluster:
+		b.Clusters()
+	case kmsg.ACLResourceTypeTransactionalId:
+		b.TransactionalIDs(names...)
+	case kmsg.ACLResourceTypeDelegationToken:
+		b.DelegationTokens(names...)
+	default:
+		return nil, fmt.Errorf("resource type %s is not supported", resourceType)
+	}
+
+	pattern := kadm.ACLPatternAny
+	if create {
+		pattern = kadm.ACLPatternLiteral
+	}
+	if f.Pattern != "" {
+		p, err := kmsg.ParseACLResourcePatternType(f.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("unknown pattern type %q", f.Pattern)
+		}
+		pattern = p
+	}
+	b.ResourcePatternType(pattern)
+
+	ops := []kadm.ACLOperation{kadm.OpAny}
+	if len(f.Operations) > 0 {
+		ops = ops[:0]
+		for _, name := range f.Operations {
+			op, err := kmsg.ParseACLOperation(name)
+			if err != nil {
+				return nil, fmt.Errorf("unknown operation %q", name)
+			}
+			ops = append(ops, op)
+		}
+	}
+	b.Operations(ops...)
+
+	permission := kmsg.ACLPermissionTypeAny
+	if f.Permission != "" {
+		p, err := kmsg.ParseACLPermissionType(f.Permission)
+		if err != nil {
+			return nil, fmt.Errorf("unknown permission %q", f.Permission)
+		}
+		permission = p
+	}
+	if create && permission == kmsg.ACLPermissionTypeAny {
+		return nil, fmt.Errorf("permission must be allow or deny")
+	}
+
+	var principals, hosts []string
+	if f.Principal != "" {
+		principals = []string{f.Principal}
+	}
+	if f.Host != "" {
+		hosts = []string{f.Host}
+	} else if create {
+		hosts = []string{"*"}
+	}
+	if permission != kmsg.ACLPermissionTypeDeny {
+		b.Allow(principals...).AllowHosts(hosts...)
+	}
+	if permission != kmsg.ACLPermissionTypeAllow {
+		b.Deny(principals...).DenyHosts(hosts...)
+	}
+
+	validate := b.ValidateDelete
+	if create {
+		validate = b.ValidateCreate
+	}
+	if err := validate(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
@@ -0,0 +1,45 @@
+package app
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	kafkaadmin "mojosoftware.dev/lazykafka/internal/kafka_admin"
+	"mojosoftware.dev/lazykafka/internal/ui"
+)
+
+type BrokerConfigsLoadedMsg struct {
+	Broker  int32
+	Entries []ui.ConfigEntry
+	Err     error
+}
+
+type BrokerConfigsAlteredMsg struct {
+	Broker int32
+	Err    error
+}
+
+// FetchBrokerConfigsCmd describes a broker's configs, or the cluster-wide
+// defaults for kafkaadmin.AllBrokers.
+func FetchBrokerConfigsCmd(client *kafkaadmin.Client, broker int32) tea.Cmd {
+	return func() tea.Msg {
+		configs, err := client.DescribeBrokerConfigs(context.Background(), broker)
+		if err != nil {
+			return BrokerConfigsLoadedMsg{Broker: broker, Err: err}
+		}
+
+		ownSource := kmsg.ConfigSourceDynamicBrokerConfig
+		if broker == kafkaadmin.AllBrokers {
+			ownSource = kmsg.ConfigSourceDynamicDefaultBrokerConfig
+		}
+		return BrokerConfigsLoadedMsg{Broker: broker, Entries: configEntries(configs, ownSource)}
+	}
+}
+
+func AlterBrokerConfigsCmd(client *kafkaadmin.Client, broker int32, changes []ui.ConfigChange) tea.Cmd {
+	return func() tea.Msg {
+		err := client.AlterBrokerConfigs(context.Background(), broker, alterConfigs(changes))
+		return BrokerConfigsAlteredMsg{Broker: broker, Err: err}
+	}
+}
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"context"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/twmb/franz-go/pkg/kadm"
+	kafkaadmin "mojosoftware.dev/lazykafka/internal/kafka_admin"
+	"mojosoftware.dev/lazykafka/internal/ui"
+)
+
+type BrokersLoadedMsg struct {
+	Client  *kafkaadmin.Client
+	Cluster ui.ClusterInfo
+	Err     error
+}
+
+func FetchBrokersCmd(client *kafkaadmin.Client) tea.Cmd {
+	return func() tea.Msg {
+		metadata, err := client.Metadata(context.Background())
+		if err != nil {
+			return BrokersLoadedMsg{Client: client, Err: err}
+		}
+		return BrokersLoadedMsg{Client: client, Cluster: clusterInfo(metadata)}
+	}
+}
+
+// clusterInfo tallies partition leadership and replica placement per broker
+// and per topic, so imbalances show up without walking every partition.
+func clusterInfo(metadata kadm.Metadata) ui.ClusterInfo {
+	cluster := ui.ClusterInfo{
+		ClusterID:  metadata.Cluster,
+		Controller: metadata.Controller,
+	}
+
+	index := make(map[int32]int, len(metadata.Brokers))
+	topicLoads := make([]map[string]*ui.BrokerTopicLoad, len(metadata.Brokers))
+	for i, b := range metadata.Brokers {
+		broker := ui.BrokerInfo{
+			NodeID:     b.NodeID,
+			Host:       b.Host,
+			Port:       b.Port,
+			Controller: b.NodeID == metadata.Controller,
+		}
+		if b.Rack != nil {
+			broker.Rack = *b.Rack
+		}
+		cluster.Brokers = append(cluster.Brokers, broker)
+		index[b.NodeID] = i
+		topicLoads[i] = make(map[string]*ui.BrokerTopicLoad)
+	}
+
+	load := func(i int, topic string) *ui.BrokerTopicLoad {
+		l, ok := topicLoads[i][topic]
+		if !ok {
+			l = &ui.BrokerTopicLoad{Topic: topic}
+			topicLoads[i][topic] = l
+		}
+		return l
+	}
+
+	for _, topic := range metadata.Topics {
+		for _, p := range topic.Partitions {
+			cluster.Partitions++
+			cluster.Replicas += len(p.Replicas)
+			if i, ok := index[p.Leader]; ok {
+				cluster.Brokers[i].Leaders++
+				load(i, topic.Topic).Leaders++
+			}
+			for _, replica := range p.Replicas {
+				if i, ok := index[replica]; ok {
+					cluster.Brokers[i].Replicas++
+					load(i, topic.Topic).Replicas++
+				}
+			}
+		}
+	}
+
+	for i := range cluster.Brokers {
+		for _, l := range topicLoads[i] {
+			cluster.Brokers[i].Topics = append(cluster.Brokers[i].Topics, *l)
+		}
+		topics := cluster.Brokers[i].Topics
+		sort.Slice(topics, func(a, b int) bool { return topics[a].Topic < topics[b].Topic })
+	}
+	return cluster
+}
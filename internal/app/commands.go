@@ -2,21 +2,46 @@ package app
 
 import (
 	"context"
+	"fmt"
 	"time"
 
-	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/log"
 	"github.com/twmb/franz-go/pkg/kgo"
 	kafkaadmin "mojosoftware.dev/lazykafka/internal/kafka_admin"
+	"mojosoftware.dev/lazykafka/internal/serde"
+	"mojosoftware.dev/lazykafka/internal/ui"
 )
 
 type TopicItem struct {
-	Name string
+	Name              string
+	Partitions        int
+	ReplicationFactor int
+	Internal          bool
+	UnderReplicated   int
+	Messages          int64 // -1 when the offsets could not be listed
+	Size              int64 // bytes on disk across all replicas, -1 when unknown
 }
 
-func (t TopicItem) Title() string       { return t.Name }
-func (t TopicItem) Description() string { return "" }
+func (t TopicItem) Title() string { return t.Name }
+func (t TopicItem) Description() string {
+	messages := "?"
+	if t.Messages >= 0 {
+		messages = "~" + formatCount(t.Messages)
+	}
+	size := "?"
+	if t.Size >= 0 {
+		size = ui.FormatBytes(t.Size)
+	}
+	desc := fmt.Sprintf("%4d partitions • RF %-2d • %7s msgs • %10s", t.Partitions, t.ReplicationFactor, messages, size)
+	if t.UnderReplicated > 0 {
+		desc += fmt.Sprintf(" • %d under-replicated", t.UnderReplicated)
+	}
+	if t.Internal {
+		desc += " • internal"
+	}
+	return desc
+}
 func (t TopicItem) FilterValue() string { return t.Name }
 
 type DownloadCompleteMsg struct {
@@ -24,24 +49,104 @@ type DownloadCompleteMsg struct {
 	Err     error
 }
 
+// KafkaMessageReceivedMsg carries a batch of records read from Chan, so
+// batches from a consumer that has since been replaced can be dropped.
 type KafkaMessageReceivedMsg struct {
 	Records []*kgo.Record
+	Chan    <-chan *kgo.Record
+}
+
+// ConsumerFailedMsg reports a consumer that could not be started.
+type ConsumerFailedMsg struct {
+	Topic string
+	Err   error
+}
+
+// MessageProducedMsg reports a record produced from the form. BuildErr is
+// set when its fields could not be encoded, Err when producing failed.
+type MessageProducedMsg struct {
+	BuildErr error
+	Err      error
 }
 
 type TopicsLoadedMsg struct {
-	Items []list.Item
+	Client *kafkaadmin.Client
+	Topics []TopicItem
 }
 
-func StartConsumerCmd(client *kafkaadmin.Client, ctx context.Context, topicName string, recordChan chan *kgo.Record) tea.Cmd {
-	go func() {
-		err := client.ConsumeMessages(ctx, topicName, recordChan)
-		if err != nil && err != context.Canceled {
-			log.Errorf("Consumer error: %v", err)
+// StartConsumerCmd reads topicName from start into recordChan. Only the
+// given partitions are read, or all of them when partitions is nil.
+func StartConsumerCmd(client *kafkaadmin.Client, ctx context.Context, topicName string, start ui.StartPosition, partitions []int32, recordChan chan *kgo.Record) tea.Cmd {
+	return func() tea.Msg {
+		offset, offsets, err := consumeOffsets(ctx, client, topicName, start, partitions)
+		if err != nil {
+			close(recordChan)
+			return ConsumerFailedMsg{Topic: topicName, Err: err}
+		}
+
+		go func() {
+			err := client.ConsumeMessages(ctx, topicName, offset, offsets, recordChan)
+			if err != nil && err != context.Canceled {
+				log.Errorf("Consumer error: %v", err)
+			}
+			close(recordChan)
+		}()
+
+		return WaitForMessageCmd(ctx, recordChan)()
+	}
+}
+
+// consumeOffsets maps a start position and partition filter onto the
+// offsets ConsumeMessages takes. Without a filter or offsets given per
+// partition, the start applies to every partition, including any added
+// while reading.
+func consumeOffsets(ctx context.Context, client *kafkaadmin.Client, topicName string, start ui.StartPosition, partitions []int32) (kgo.Offset, map[int32]kgo.Offset, error) {
+	offset := kgo.NewOffset().AtStart()
+	switch start.Kind {
+	case ui.StartLatest:
+		offset = kgo.NewOffset().AtEnd()
+	case ui.StartTimestamp:
+		offset = kgo.NewOffset().AfterMilli(start.Time.UnixMilli())
+	case ui.StartLastN:
+		// Partitions holding fewer than Count records start at their beginning.
+		offset = kgo.NewOffset().AtEnd().Relative(-start.Count)
+	case ui.StartOffset:
+		if start.Offset >= 0 {
+			offset = kgo.NewOffset().At(start.Offset)
+		}
+	}
+	if partitions == nil && len(start.Offsets) == 0 {
+		return offset, nil, nil
+	}
+
+	detail, err := client.DescribeTopic(ctx, topicName)
+	if err != nil {
+		return offset, nil, err
+	}
+	if partitions == nil {
+		for p := range detail.Partitions {
+			partitions = append(partitions, p)
+		}
+	}
+	for _, p := range partitions {
+		if _, ok := detail.Partitions[p]; !ok {
+			return offset, nil, fmt.Errorf("topic %s has no partition %d", topicName, p)
 		}
-		close(recordChan)
-	}()
+	}
+	for p := range start.Offsets {
+		if _, ok := detail.Partitions[p]; !ok {
+			return offset, nil, fmt.Errorf("topic %s has no partition %d", topicName, p)
+		}
+	}
 
-	return WaitForMessageCmd(ctx, recordChan)
+	offsets := make(map[int32]kgo.Offset, len(partitions))
+	for _, p := range partitions {
+		offsets[p] = offset
+		if at, ok := start.Offsets[p]; ok {
+			offsets[p] = kgo.NewOffset().At(at)
+		}
+	}
+	return offset, offsets, nil
 }
 
 func WaitForMessageCmd(
@@ -61,18 +166,18 @@ func WaitForMessageCmd(
 			case r, ok := <-recordChan:
 				if !ok {
 					if len(batch) > 0 {
-						return KafkaMessageReceivedMsg{Records: batch}
+						return KafkaMessageReceivedMsg{Records: batch, Chan: recordChan}
 					}
 					return nil
 				}
 				batch = append(batch, r)
 				if len(batch) >= maxBatch {
-					return KafkaMessageReceivedMsg{Records: batch}
+					return KafkaMessageReceivedMsg{Records: batch, Chan: recordChan}
 				}
 
 			case <-timer.C:
 				if len(batch) > 0 {
-					return KafkaMessageReceivedMsg{Records: batch}
+					return KafkaMessageReceivedMsg{Records: batch, Chan: recordChan}
 				}
 				timer.Reset(maxWait)
 
@@ -89,21 +194,76 @@ func FetchTopicsCmd(client *kafkaadmin.Client) tea.Cmd {
 		if err != nil {
 			return nil
 		}
-		topics := make([]list.Item, 0, len(topicDetails))
-		for topicName := range topicDetails {
-			topics = append(topics, TopicItem{Name: topicName})
+
+		// Offsets only feed the approximate message count, so a failure
+		// here still lists the topics.
+		startOffsets, endOffsets, err := client.ListOffsets(ctx, topicDetails.Names()...)
+		if err != nil {
+			log.Errorf("Failed to list offsets: %v", err)
 		}
-		return TopicsLoadedMsg{Items: topics}
+
+		topics := make([]TopicItem, 0, len(topicDetails))
+		for topicName, detail := range topicDetails {
+			item := TopicItem{
+				Name:              topicName,
+				Partitions:        len(detail.Partitions),
+				ReplicationFactor: detail.Partitions.NumReplicas(),
+				Internal:          detail.IsInternal,
+				Messages:          -1,
+				Size:              -1,
+			}
+			for _, p := range detail.Partitions {
+				if len(p.ISR) < len(p.Replicas) {
+					item.UnderReplicated++
+				}
+			}
+			if err == nil {
+				item.Messages = 0
+				for _, end := range endOffsets[topicName] {
+					start, ok := startOffsets.Lookup(topicName, end.Partition)
+					if ok && start.Err == nil && end.Err == nil {
+						item.Messages += end.Offset - start.Offset
+					}
+				}
+			}
+			topics = append(topics, item)
+		}
+		return TopicsLoadedMsg{Client: client, Topics: topics}
 	}
 }
 
-func DownloadTopicCmd(client *kafkaadmin.Client, topicName, filePath string) tea.Cmd {
+func DownloadTopicCmd(client *kafkaadmin.Client, topicName, filePath string, key, value serde.Deserializer) tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
-		err := client.DownloadTopic(ctx, topicName, filePath)
+		err := client.DownloadTopic(ctx, topicName, filePath, key, value)
 		if err != nil {
 			return DownloadCompleteMsg{Success: false, Err: err}
 		}
 		return DownloadCompleteMsg{Success: true, Err: nil}
 	}
 }
+
+// ProduceMessageCmd encodes the form's fields with the picked serializers,
+// which may look schemas up in the registry, and produces the record.
+func ProduceMessageCmd(client *kafkaadmin.Client, serializers *serde.Registry, topicName string, message ui.ProduceMsg) tea.Cmd {
+	return func() tea.Msg {
+		record, err := buildRecord(client, serializers, topicName, message)
+		if err != nil {
+			return MessageProducedMsg{BuildErr: err}
+		}
+		return MessageProducedMsg{Err: client.ProduceMessage(context.Background(), &record)}
+	}
+}
+
+func buildRecord(client *kafkaadmin.Client, serializers *serde.Registry, topicName string, message ui.ProduceMsg) (kgo.Record, error) {
+	keySerde, err := serializers.Serializer(message.KeySerde)
+	if err != nil {
+		return kgo.Record{}, err
+	}
+	valueSerde, err := serializers.Serializer(message.ValueSerde)
+	if err != nil {
+		return kgo.Record{}, err
+	}
+	return client.BuildRecord(topicName, message.PartitionNumber, keySerde, valueSerde,
+		message.Key, message.Value, message.Headers)
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/log"
+	"github.com/twmb/franz-go/pkg/kadm"
+	kafkaadmin "mojosoftware.dev/lazykafka/internal/kafka_admin"
+	"mojosoftware.dev/lazykafka/internal/ui"
+)
+
+type DiskUsageLoadedMsg struct {
+	Client *kafkaadmin.Client
+	Usage  ui.DiskUsage
+	Err    error
+}
+
+func FetchDiskUsageCmd(client *kafkaadmin.Client) tea.Cmd {
+	return func() tea.Msg {
+		dirs, err := client.DescribeLogDirs(context.Background())
+		if err != nil && len(dirs) == 0 {
+			return DiskUsageLoadedMsg{Client: client, Err: err}
+		}
+
+		usage := diskUsage(dirs)
+		if err != nil {
+			usage.Warning = err.Error()
+		}
+		return DiskUsageLoadedMsg{Client: client, Usage: usage}
+	}
+}
+
+func diskUsage(dirs kadm.DescribedAllLogDirs) ui.DiskUsage {
+	var usage ui.DiskUsage
+	for _, d := range dirs.Sorted() {
+		usage.Dirs = append(usage.Dirs, ui.LogDir{Broker: d.Broker, Dir: d.Dir, Err: d.Err})
+		for _, p := range d.Topics.Sorted() {
+			usage.Replicas = append(usage.Replicas, ui.LogDirReplica{
+				Broker:    p.Broker,
+				Dir:       p.Dir,
+				Topic:     p.Topic,
+				Partition: p.Partition,
+				Size:      p.Size,
+				OffsetLag: p.OffsetLag,
+				Future:    p.IsFuture,
+			})
+		}
+	}
+	return usage
+}
+
+// TopicSizesLoadedMsg carries each topic's size on disk, for the topics
+// the brokers that answered hold replicas of.
+type TopicSizesLoadedMsg struct {
+	Client *kafkaadmin.Client
+	Sizes  map[string]int64
+}
+
+// FetchTopicSizesCmd describes log dirs apart from the topic list, as it
+// asks every broker and needs cluster describe access, which not every
+// principal has. Dirs from the brokers that answered are kept even when
+// others failed.
+func FetchTopicSizesCmd(client *kafkaadmin.Client) tea.Cmd {
+	return func() tea.Msg {
+		dirs, err := client.DescribeLogDirs(context.Background())
+		if err != nil && len(dirs) == 0 {
+			log.Errorf("Failed to describe log dirs: %v", err)
+			return nil
+		}
+		return TopicSizesLoadedMsg{Client: client, Sizes: topicSizes(dirs)}
+	}
+}
+
+// WithSizes fills in the size of each topic in sizes, leaving the others
+// unknown.
+func WithSizes(topics []TopicItem, sizes map[string]int64) {
+	for i := range topics {
+		topics[i].Size = -1
+		if size, ok := sizes[topics[i].Name]; ok {
+			topics[i].Size = size
+		}
+	}
+}
+
+// topicSizes sums the on-disk size of every replica of each topic.
+func topicSizes(dirs kadm.DescribedAllLogDirs) map[string]int64 {
+	sizes := make(map[string]int64)
+	dirs.Each(func(d kadm.DescribedLogDir) {
+		for topic, partitions := range d.Topics {
+			for _, p := range partitions {
+				sizes[topic] += p.Size
+			}
+		}
+	})
+	return sizes
+}
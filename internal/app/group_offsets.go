@@ -0,0 +1,137 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/twmb/franz-go/pkg/kadm"
+	kafkaadmin "mojosoftware.dev/lazykafka/internal/kafka_admin"
+	"mojosoftware.dev/lazykafka/internal/ui"
+)
+
+type OffsetResetPlannedMsg struct {
+	Rows []ui.OffsetResetRow
+	Err  error
+}
+
+type OffsetsResetMsg struct {
+	Group string
+	Count int
+	Err   error
+}
+
+// PlanOffsetResetCmd resolves a reset request into the offsets it would
+// commit, without committing anything.
+func PlanOffsetResetCmd(client *kafkaadmin.Client, req ui.OffsetResetRequest) tea.Cmd {
+	return func() tea.Msg {
+		rows, err := planOffsetReset(context.Background(), client, req)
+		return OffsetResetPlannedMsg{Rows: rows, Err: err}
+	}
+}
+
+func ResetOffsetsCmd(client *kafkaadmin.Client, group string, rows []ui.OffsetResetRow) tea.Cmd {
+	return func() tea.Msg {
+		offsets := make(kadm.Offsets)
+		for _, r := range rows {
+			offsets.Add(kadm.Offset{Topic: r.Topic, Partition: r.Partition, At: r.New, LeaderEpoch: -1})
+		}
+		err := client.CommitGroupOffsets(context.Background(), group, offsets)
+		return OffsetsResetMsg{Group: group, Count: len(rows), Err: err}
+	}
+}
+
+func planOffsetReset(ctx context.Context, client *kafkaadmin.Client, req ui.OffsetResetRequest) ([]ui.OffsetResetRow, error) {
+	if err := client.CheckGroupInactive(ctx, req.Group); err != nil {
+		return nil, err
+	}
+
+	committed, err := client.CommittedOffsets(ctx, req.Group)
+	if err != nil {
+		return nil, err
+	}
+
+	topics := make([]string, 0, len(req.Partitions))
+	for topic := range req.Partitions {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+
+	start, end, err := client.ListOffsets(ctx, topics...)
+	if err != nil {
+		return nil, err
+	}
+
+	var atTime kadm.ListedOffsets
+	if req.Strategy == ui.ResetToDatetime {
+		if atTime, err = client.ListOffsetsAfter(ctx, req.Timestamp, topics...); err != nil {
+			return nil, err
+		}
+	}
+
+	var rows []ui.OffsetResetRow
+	for _, topic := range topics {
+		partitions := append([]int32(nil), req.Partitions[topic]...)
+		sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
+
+		for _, partition := range partitions {
+			row := ui.OffsetResetRow{Topic: topic, Partition: partition, Old: -1}
+			if c, ok := committed.Lookup(topic, partition); ok && c.Err == nil {
+				row.Old = c.At
+			}
+			row.New, row.Err = resetTarget(req, row.Old, topic, partition, start, end, atTime)
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+// resetTarget works out one partition's new offset. Explicit and shifted
+// offsets are clamped to the partition's current range, as the broker would
+// otherwise leave the group pointing at data that does not exist.
+func resetTarget(req ui.OffsetResetRequest, old int64, topic string, partition int32, start, end, atTime kadm.ListedOffsets) (int64, error) {
+	s, ok := start.Lookup(topic, partition)
+	if !ok {
+		return 0, errors.New("partition not found")
+	}
+	e, ok := end.Lookup(topic, partition)
+	if !ok {
+		return 0, errors.New("partition not found")
+	}
+	if s.Err != nil {
+		return 0, s.Err
+	}
+	if e.Err != nil {
+		return 0, e.Err
+	}
+
+	clamp := func(offset int64) int64 {
+		return min(max(offset, s.Offset), e.Offset)
+	}
+
+	switch req.Strategy {
+	case ui.ResetToEarliest:
+		return s.Offset, nil
+	case ui.ResetToLatest:
+		return e.Offset, nil
+	case ui.ResetToDatetime:
+		t, ok := atTime.Lookup(topic, partition)
+		if !ok {
+			return 0, errors.New("no offset for timestamp")
+		}
+		if t.Err != nil {
+			return 0, t.Err
+		}
+		return clamp(t.Offset), nil
+	case ui.ResetToOffset:
+		return clamp(req.Offset), nil
+	case ui.ResetShiftBy:
+		if old < 0 {
+			return 0, errors.New("no committed offset to shift from")
+		}
+		return clamp(old + req.Offset), nil
+	}
+	return 0, fmt.Errorf("unknown strategy %v", req.Strategy)
+}
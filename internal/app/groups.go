@@ -0,0 +1,163 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/twmb/franz-go/pkg/kadm"
+	kafkaadmin "mojosoftware.dev/lazykafka/internal/kafka_admin"
+	"mojosoftware.dev/lazykafka/internal/ui"
+)
+
+type GroupsLoadedMsg struct {
+	Client *kafkaadmin.Client
+	Groups []ui.GroupSummary
+	Err    error
+}
+
+type GroupDetailLoadedMsg struct {
+	Name   string
+	Detail ui.GroupDetail
+	Err    error
+}
+
+type GroupsDeletedMsg struct {
+	Results []ui.ItemResult
+	Err     error
+}
+
+type OffsetsDeletedMsg struct {
+	Group   string
+	Results []ui.ItemResult
+	Err     error
+}
+
+func FetchGroupsCmd(client *kafkaadmin.Client) tea.Cmd {
+	return func() tea.Msg {
+		lags, err := client.GroupLags(context.Background())
+		if err != nil {
+			return GroupsLoadedMsg{Client: client, Err: err}
+		}
+
+		sorted := lags.Sorted()
+		groups := make([]ui.GroupSummary, len(sorted))
+		for i, l := range sorted {
+			groups[i] = groupSummary(l)
+		}
+		return GroupsLoadedMsg{Client: client, Groups: groups}
+	}
+}
+
+func FetchGroupDetailCmd(client *kafkaadmin.Client, name string) tea.Cmd {
+	return func() tea.Msg {
+		lags, err := client.GroupLags(context.Background(), name)
+		if err != nil {
+			return GroupDetailLoadedMsg{Name: name, Err: err}
+		}
+		l, ok := lags[name]
+		if !ok {
+			return GroupDetailLoadedMsg{Name: name, Err: fmt.Errorf("group %q not found", name)}
+		}
+		if err := l.Error(); err != nil {
+			return GroupDetailLoadedMsg{Name: name, Err: err}
+		}
+		return GroupDetailLoadedMsg{Name: name, Detail: groupDetail(l)}
+	}
+}
+
+func groupSummary(l kadm.DescribedGroupLag) ui.GroupSummary {
+	return ui.GroupSummary{
+		Name:         l.Group,
+		State:        l.State,
+		ProtocolType: l.ProtocolType,
+		Protocol:     l.Protocol,
+		Members:      len(l.Members),
+		Lag:          l.Lag.Total(),
+		Err:          l.Error(),
+	}
+}
+
+func groupDetail(l kadm.DescribedGroupLag) ui.GroupDetail {
+	detail := ui.GroupDetail{GroupSummary: groupSummary(l)}
+
+	for _, m := range l.Members {
+		member := ui.GroupMember{
+			MemberID:   m.MemberID,
+			ClientID:   m.ClientID,
+			ClientHost: m.ClientHost,
+			Assigned:   make(map[string][]int32),
+		}
+		if m.InstanceID != nil {
+			member.InstanceID = *m.InstanceID
+		}
+		if assigned, ok := m.Assigned.AsConsumer(); ok {
+			for _, t := range assigned.Topics {
+				member.Assigned[t.Topic] = t.Partitions
+			}
+		}
+		detail.Members = append(detail.Members, member)
+	}
+
+	for _, p := range l.Lag.Sorted() {
+		partition := ui.GroupPartitionLag{
+			Topic:     p.Topic,
+			Partition: p.Partition,
+			Committed: p.Commit.At,
+			End:       p.End.Offset,
+			Lag:       p.Lag,
+			Err:       p.Err,
+		}
+		if p.Member != nil {
+			partition.ClientID = p.Member.ClientID
+		}
+		detail.Partitions = append(detail.Partitions, partition)
+	}
+	return detail
+}
+
+func DeleteGroupsCmd(client *kafkaadmin.Client, groups []string) tea.Cmd {
+	return func() tea.Msg {
+		responses, err := client.DeleteGroups(context.Background(), groups...)
+		if err != nil {
+			return GroupsDeletedMsg{Err: err}
+		}
+
+		var results []ui.ItemResult
+		for _, r := range responses.Sorted() {
+			results = append(results, ui.ItemResult{Name: r.Group, Err: r.Err})
+		}
+		return GroupsDeletedMsg{Results: results}
+	}
+}
+
+func DeleteOffsetsCmd(client *kafkaadmin.Client, group string, partitions map[string][]int32) tea.Cmd {
+	return func() tea.Msg {
+		responses, err := client.DeleteOffsets(context.Background(), group, partitions)
+		if err != nil {
+			return OffsetsDeletedMsg{Group: group, Err: err}
+		}
+
+		topics := make([]string, 0, len(partitions))
+		for topic := range partitions {
+			topics = append(topics, topic)
+		}
+		sort.Strings(topics)
+
+		var results []ui.ItemResult
+		for _, topic := range topics {
+			ps := append([]int32(nil), partitions[topic]...)
+			sort.Slice(ps, func(i, j int) bool { return ps[i] < ps[j] })
+			for _, p := range ps {
+				err, ok := responses.Lookup(topic, p)
+				if !ok {
+					err = errors.New("no response from the broker")
+				}
+				results = append(results, ui.ItemResult{Name: fmt.Sprintf("%s [%d]", topic, p), Err: err})
+			}
+		}
+		return OffsetsDeletedMsg{Group: group, Results: results}
+	}
+}
@@ -2,11 +2,13 @@ package app
 
 import (
 	"context"
+	"fmt"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/log"
 	overlay "github.com/rmhubbert/bubbletea-overlay"
 	kafkaadmin "mojosoftware.dev/lazykafka/internal/kafka_admin"
+	"mojosoftware.dev/lazykafka/internal/serde"
 	"mojosoftware.dev/lazykafka/internal/ui"
 )
 
@@ -18,6 +20,17 @@ const (
 	OverlayDeleteTopic
 	OverlayProduceMessage
 	OverlayDownloadTopic
+	OverlayClusterPicker
+	OverlayAddPartitions
+	OverlayDeleteGroups
+	OverlayDeleteOffsets
+	OverlayResults
+	OverlayACL
+	OverlayDeleteACLs
+	OverlaySCRAMUser
+	OverlayDeleteSCRAM
+	OverlayQuota
+	OverlayDeleteQuota
 )
 
 type OverlayManager struct {
@@ -26,7 +39,25 @@ type OverlayManager struct {
 	deleteTopicForm    ui.DeleteTopicForm
 	produceMessageForm ui.ProduceMessageForm
 	downloadTopicForm  ui.DownloadTopicForm
+	clusterPicker      ui.ProfilePicker
+	addPartitionsForm  ui.AddPartitionsForm
+	deleteGroupsForm   ui.DeleteGroupsForm
+	deleteOffsetsForm  ui.DeleteOffsetsForm
+	resultsForm        ui.ResultsForm
+	aclForm            ui.ACLForm
+	deleteACLsForm     ui.DeleteACLsForm
+	scramUserForm      ui.SCRAMUserForm
+	deleteSCRAMForm    ui.DeleteSCRAMForm
+	quotaForm          ui.QuotaForm
+	deleteQuotaForm    ui.DeleteQuotaForm
 	selectedTopic      string
+	selectedGroups     []string
+	selectedPartitions map[string][]int32
+	selectedACLFilter  ui.ACLFilter
+	selectedSCRAM      ui.SCRAMCredential
+	selectedQuota      ui.ClientQuota
+	serializers        *serde.Registry
+	producing          bool
 }
 
 func NewOverlayManager() OverlayManager {
@@ -56,10 +87,12 @@ func (om *OverlayManager) OpenDeleteTopic(topicName string) {
 	om.deleteTopicForm = ui.NewDeleteTopicForm(topicName)
 }
 
-func (om *OverlayManager) OpenProduceMessage(topicName string) {
+func (om *OverlayManager) OpenProduceMessage(topicName, keySerde, valueSerde string, serializers *serde.Registry) {
 	om.active = OverlayProduceMessage
 	om.selectedTopic = topicName
-	om.produceMessageForm = ui.NewProduceMessageForm(topicName)
+	om.serializers = serializers
+	om.producing = false
+	om.produceMessageForm = ui.NewProduceMessageForm(topicName, keySerde, valueSerde, serializers.Serializers())
 }
 
 func (om *OverlayManager) OpenDownloadTopic(topicName string) {
@@ -68,6 +101,78 @@ func (om *OverlayManager) OpenDownloadTopic(topicName string) {
 	om.downloadTopicForm = ui.NewDownloadTopicForm(topicName)
 }
 
+func (om *OverlayManager) OpenClusterPicker(profiles []ui.ProfileItem) {
+	om.active = OverlayClusterPicker
+	om.clusterPicker = ui.NewProfilePicker("Switch Cluster", profiles)
+}
+
+func (om *OverlayManager) OpenAddPartitions(topicName string, current int) {
+	om.active = OverlayAddPartitions
+	om.selectedTopic = topicName
+	om.addPartitionsForm = ui.NewAddPartitionsForm(topicName, current)
+}
+
+func (om *OverlayManager) OpenDeleteGroups(groups []string) {
+	om.active = OverlayDeleteGroups
+	om.selectedGroups = groups
+	om.deleteGroupsForm = ui.NewDeleteGroupsForm(groups)
+}
+
+func (om *OverlayManager) OpenDeleteOffsets(group string, partitions map[string][]int32) {
+	om.active = OverlayDeleteOffsets
+	om.selectedGroups = []string{group}
+	om.selectedPartitions = partitions
+	om.deleteOffsetsForm = ui.NewDeleteOffsetsForm(group, partitions)
+}
+
+// OpenResults shows the per-item outcome of a batch operation.
+func (om *OverlayManager) OpenResults(title string, results []ui.ItemResult) {
+	om.active = OverlayResults
+	om.resultsForm = ui.NewResultsForm(title, results)
+}
+
+func (om *OverlayManager) OpenCreateACL() {
+	om.active = OverlayACL
+	om.aclForm = ui.NewCreateACLForm()
+}
+
+func (om *OverlayManager) OpenDeleteACLFilter(filter ui.ACLFilter) {
+	om.active = OverlayACL
+	om.aclForm = ui.NewDeleteACLForm(filter)
+}
+
+// OpenDeleteACLs confirms a delete filter, listing the bindings it matched.
+func (om *OverlayManager) OpenDeleteACLs(filter ui.ACLFilter, matches []ui.ACLBinding) {
+	om.active = OverlayDeleteACLs
+	om.selectedACLFilter = filter
+	om.deleteACLsForm = ui.NewDeleteACLsForm(matches)
+}
+
+// OpenSCRAMUser creates a user when user is empty, and otherwise rotates
+// that user's password for mechanism.
+func (om *OverlayManager) OpenSCRAMUser(user, mechanism string, iterations int32) {
+	om.active = OverlaySCRAMUser
+	om.scramUserForm = ui.NewSCRAMUserForm(user, mechanism, iterations)
+}
+
+func (om *OverlayManager) OpenDeleteSCRAM(user, mechanism string) {
+	om.active = OverlayDeleteSCRAM
+	om.selectedSCRAM = ui.SCRAMCredential{User: user, Mechanism: mechanism}
+	om.deleteSCRAMForm = ui.NewDeleteSCRAMForm(user, mechanism)
+}
+
+// OpenQuota edits the quota's values, or adds a new entity when it has none.
+func (om *OverlayManager) OpenQuota(quota ui.ClientQuota) {
+	om.active = OverlayQuota
+	om.quotaForm = ui.NewQuotaForm(quota)
+}
+
+func (om *OverlayManager) OpenDeleteQuota(quota ui.ClientQuota) {
+	om.active = OverlayDeleteQuota
+	om.selectedQuota = quota
+	om.deleteQuotaForm = ui.NewDeleteQuotaForm(quota)
+}
+
 func (om *OverlayManager) Update(
 	msg tea.Msg,
 	client *kafkaadmin.Client,
@@ -79,9 +184,13 @@ func (om *OverlayManager) Update(
 		return false, nil
 	}
 
-	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+	// While the cluster picker's filter is being typed, esc clears it.
+	filtering := om.active == OverlayClusterPicker && om.clusterPicker.Filtering()
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && !filtering {
 		if keyMsg.String() == "esc" {
 			om.Close()
+			// Don't keep a half-typed password around.
+			om.scramUserForm = ui.SCRAMUserForm{}
 			return true, nil
 		}
 	}
@@ -92,9 +201,31 @@ func (om *OverlayManager) Update(
 	case OverlayDeleteTopic:
 		return om.handleDeleteTopic(msg, client, toastMgr, fetchTopicsCmd)
 	case OverlayProduceMessage:
-		return om.handleProduceMessage(msg, client, toastMgr)
+		return om.handleProduceMessage(msg, client)
 	case OverlayDownloadTopic:
 		return om.handleDownloadTopic(msg, client, toastMgr, downloadTopicCmd)
+	case OverlayClusterPicker:
+		return om.handleClusterPicker(msg)
+	case OverlayAddPartitions:
+		return om.handleAddPartitions(msg, client, toastMgr, fetchTopicsCmd)
+	case OverlayDeleteGroups:
+		return om.handleDeleteGroups(msg, client)
+	case OverlayDeleteOffsets:
+		return om.handleDeleteOffsets(msg, client)
+	case OverlayResults:
+		return om.handleResults(msg)
+	case OverlayACL:
+		return om.handleACLForm(msg, client)
+	case OverlayDeleteACLs:
+		return om.handleDeleteACLs(msg, client)
+	case OverlaySCRAMUser:
+		return om.handleSCRAMUser(msg, client)
+	case OverlayDeleteSCRAM:
+		return om.handleDeleteSCRAM(msg, client)
+	case OverlayQuota:
+		return om.handleQuota(msg, client)
+	case OverlayDeleteQuota:
+		return om.handleDeleteQuota(msg, client)
 	}
 	return false, nil
 }
@@ -106,14 +237,18 @@ func (om *OverlayManager) handleCreateTopic(
 	fetchTopicsCmd func(*kafkaadmin.Client) tea.Cmd,
 ) (bool, tea.Cmd) {
 	if topic, ok := msg.(ui.TopicSubmittedMsg); ok {
-		om.Close()
 		ctx := context.Background()
-		_, err := client.CreateTopic(ctx, topic.TopicName)
+		_, err := client.CreateTopic(ctx, topic.TopicName, topic.Partitions, topic.ReplicationFactor, topic.Configs)
 		if err != nil {
 			log.Errorf("Failed to create topic: %v", err)
+			om.createTopicForm.SetError(err)
 			return true, nil
 		}
-		return true, fetchTopicsCmd(client)
+		om.Close()
+		return true, tea.Batch(
+			toastMgr.ShowSuccess(fmt.Sprintf("Topic %s created", topic.TopicName)),
+			fetchTopicsCmd(client),
+		)
 	}
 
 	updatedForm, cmd := om.createTopicForm.Update(msg)
@@ -145,21 +280,16 @@ func (om *OverlayManager) handleDeleteTopic(
 	return true, cmd
 }
 
-func (om *OverlayManager) handleProduceMessage(
-	msg tea.Msg,
-	client *kafkaadmin.Client,
-	toastMgr *ToastManager,
-) (bool, tea.Cmd) {
+// handleProduceMessage builds and produces the record in the background, as
+// schema registry serializers make requests; the caller hands the result,
+// app.MessageProducedMsg, to ProduceDone.
+func (om *OverlayManager) handleProduceMessage(msg tea.Msg, client *kafkaadmin.Client) (bool, tea.Cmd) {
 	if message, ok := msg.(ui.ProduceMsg); ok {
-		om.Close()
-		ctx := context.Background()
-		record, err := client.BuildRecord(om.selectedTopic, message.PartitionNumber, message.KeySerde, message.ValueSerde,
-			message.Key, message.Value, message.Headers)
-		if err != nil {
-			log.Errorf("Error: %v", err)
+		if om.producing {
+			return true, nil
 		}
-		client.ProduceMessage(ctx, &record)
-		return true, toastMgr.ShowSuccess("Message produced successfully!")
+		om.producing = true
+		return true, ProduceMessageCmd(client, om.serializers, om.selectedTopic, message)
 	}
 
 	updatedForm, cmd := om.produceMessageForm.Update(msg)
@@ -167,6 +297,29 @@ func (om *OverlayManager) handleProduceMessage(
 	return true, cmd
 }
 
+// ProduceDone reports a produce started from the form. A record that could
+// not be built leaves the form open to fix, unless it was closed meanwhile.
+func (om *OverlayManager) ProduceDone(msg MessageProducedMsg, toastMgr *ToastManager) tea.Cmd {
+	om.producing = false
+	formOpen := om.active == OverlayProduceMessage
+	if msg.BuildErr != nil && formOpen {
+		om.produceMessageForm.SetError(msg.BuildErr)
+		return nil
+	}
+	if formOpen {
+		om.Close()
+	}
+
+	err := msg.BuildErr
+	if err == nil {
+		err = msg.Err
+	}
+	if err != nil {
+		return toastMgr.ShowError(fmt.Sprintf("Failed to produce message: %v", err))
+	}
+	return toastMgr.ShowSuccess("Message produced successfully!")
+}
+
 func (om *OverlayManager) handleDownloadTopic(
 	msg tea.Msg,
 	client *kafkaadmin.Client,
@@ -190,6 +343,177 @@ func (om *OverlayManager) handleDownloadTopic(
 	return true, cmd
 }
 
+func (om *OverlayManager) handleAddPartitions(
+	msg tea.Msg,
+	client *kafkaadmin.Client,
+	toastMgr *ToastManager,
+	fetchTopicsCmd func(*kafkaadmin.Client) tea.Cmd,
+) (bool, tea.Cmd) {
+	if submitted, ok := msg.(ui.PartitionsSubmittedMsg); ok {
+		ctx := context.Background()
+		if err := client.SetPartitionCount(ctx, submitted.TopicName, submitted.Total); err != nil {
+			return true, toastMgr.ShowError(fmt.Sprintf("Failed to add partitions: %v", err))
+		}
+		om.Close()
+		return true, tea.Batch(
+			toastMgr.ShowSuccess(fmt.Sprintf("%s now has %d partitions", submitted.TopicName, submitted.Total)),
+			fetchTopicsCmd(client),
+		)
+	}
+
+	updatedForm, cmd := om.addPartitionsForm.Update(msg)
+	om.addPartitionsForm = updatedForm.(ui.AddPartitionsForm)
+	return true, cmd
+}
+
+// handleDeleteGroups runs the delete in the background; the caller reports
+// the per-group results when app.GroupsDeletedMsg arrives.
+func (om *OverlayManager) handleDeleteGroups(msg tea.Msg, client *kafkaadmin.Client) (bool, tea.Cmd) {
+	if deleteMsg, ok := msg.(ui.GroupsDeleteMsg); ok {
+		om.Close()
+		if deleteMsg.Confirmed {
+			return true, DeleteGroupsCmd(client, om.selectedGroups)
+		}
+		return true, nil
+	}
+
+	updatedForm, cmd := om.deleteGroupsForm.Update(msg)
+	om.deleteGroupsForm = updatedForm.(ui.DeleteGroupsForm)
+	return true, cmd
+}
+
+func (om *OverlayManager) handleDeleteOffsets(msg tea.Msg, client *kafkaadmin.Client) (bool, tea.Cmd) {
+	if deleteMsg, ok := msg.(ui.OffsetsDeleteMsg); ok {
+		om.Close()
+		if deleteMsg.Confirmed {
+			return true, DeleteOffsetsCmd(client, om.selectedGroups[0], om.selectedPartitions)
+		}
+		return true, nil
+	}
+
+	updatedForm, cmd := om.deleteOffsetsForm.Update(msg)
+	om.deleteOffsetsForm = updatedForm.(ui.DeleteOffsetsForm)
+	return true, cmd
+}
+
+// handleACLForm checks the fields before closing so typos stay in the form.
+// Creating reports its results through app.ACLsCreatedMsg; deleting first
+// previews the matches through app.ACLDeletePreviewMsg.
+func (om *OverlayManager) handleACLForm(msg tea.Msg, client *kafkaadmin.Client) (bool, tea.Cmd) {
+	if submitted, ok := msg.(ui.ACLSubmittedMsg); ok {
+		if _, err := aclBuilder(submitted.Filter, !submitted.Delete); err != nil {
+			om.aclForm.SetError(err)
+			return true, nil
+		}
+		om.Close()
+		if submitted.Delete {
+			return true, PreviewDeleteACLsCmd(client, submitted.Filter)
+		}
+		return true, CreateACLsCmd(client, submitted.Filter)
+	}
+
+	updatedForm, cmd := om.aclForm.Update(msg)
+	om.aclForm = updatedForm.(ui.ACLForm)
+	return true, cmd
+}
+
+func (om *OverlayManager) handleDeleteACLs(msg tea.Msg, client *kafkaadmin.Client) (bool, tea.Cmd) {
+	if deleteMsg, ok := msg.(ui.ACLsDeleteMsg); ok {
+		om.Close()
+		if deleteMsg.Confirmed {
+			return true, DeleteACLsCmd(client, om.selectedACLFilter)
+		}
+		return true, nil
+	}
+
+	updatedForm, cmd := om.deleteACLsForm.Update(msg)
+	om.deleteACLsForm = updatedForm.(ui.DeleteACLsForm)
+	return true, cmd
+}
+
+// handleSCRAMUser drops the form once submitted so the typed password does
+// not linger; the caller reports app.SCRAMUserAlteredMsg.
+func (om *OverlayManager) handleSCRAMUser(msg tea.Msg, client *kafkaadmin.Client) (bool, tea.Cmd) {
+	if submitted, ok := msg.(ui.SCRAMUserSubmittedMsg); ok {
+		om.Close()
+		om.scramUserForm = ui.SCRAMUserForm{}
+		return true, UpsertSCRAMUserCmd(client, submitted)
+	}
+
+	updatedForm, cmd := om.scramUserForm.Update(msg)
+	om.scramUserForm = updatedForm.(ui.SCRAMUserForm)
+	return true, cmd
+}
+
+func (om *OverlayManager) handleDeleteSCRAM(msg tea.Msg, client *kafkaadmin.Client) (bool, tea.Cmd) {
+	if deleteMsg, ok := msg.(ui.SCRAMDeleteMsg); ok {
+		om.Close()
+		if deleteMsg.Confirmed {
+			return true, DeleteSCRAMCredentialCmd(client, om.selectedSCRAM.User, om.selectedSCRAM.Mechanism)
+		}
+		return true, nil
+	}
+
+	updatedForm, cmd := om.deleteSCRAMForm.Update(msg)
+	om.deleteSCRAMForm = updatedForm.(ui.DeleteSCRAMForm)
+	return true, cmd
+}
+
+// handleQuota runs the alter in the background; the caller reports
+// app.QuotaAlteredMsg.
+func (om *OverlayManager) handleQuota(msg tea.Msg, client *kafkaadmin.Client) (bool, tea.Cmd) {
+	if submitted, ok := msg.(ui.QuotaSubmittedMsg); ok {
+		om.Close()
+		return true, AlterQuotaCmd(client, submitted.Entity, submitted.Set, submitted.Remove)
+	}
+
+	updatedForm, cmd := om.quotaForm.Update(msg)
+	om.quotaForm = updatedForm.(ui.QuotaForm)
+	return true, cmd
+}
+
+func (om *OverlayManager) handleDeleteQuota(msg tea.Msg, client *kafkaadmin.Client) (bool, tea.Cmd) {
+	if deleteMsg, ok := msg.(ui.QuotaDeleteMsg); ok {
+		om.Close()
+		if !deleteMsg.Confirmed {
+			return true, nil
+		}
+		keys := make([]string, 0, len(om.selectedQuota.Values))
+		for key := range om.selectedQuota.Values {
+			keys = append(keys, key)
+		}
+		return true, AlterQuotaCmd(client, om.selectedQuota.Entity, nil, keys)
+	}
+
+	updatedForm, cmd := om.deleteQuotaForm.Update(msg)
+	om.deleteQuotaForm = updatedForm.(ui.DeleteQuotaForm)
+	return true, cmd
+}
+
+func (om *OverlayManager) handleResults(msg tea.Msg) (bool, tea.Cmd) {
+	if _, ok := msg.(ui.ResultsClosedMsg); ok {
+		om.Close()
+		return true, nil
+	}
+
+	updatedForm, cmd := om.resultsForm.Update(msg)
+	om.resultsForm = updatedForm.(ui.ResultsForm)
+	return true, cmd
+}
+
+// handleClusterPicker leaves ui.ProfileSelectedMsg unhandled so the caller,
+// which owns the client and consumers, can perform the switch.
+func (om *OverlayManager) handleClusterPicker(msg tea.Msg) (bool, tea.Cmd) {
+	if _, ok := msg.(ui.ProfileSelectedMsg); ok {
+		om.Close()
+		return false, nil
+	}
+
+	updatedPicker, cmd := om.clusterPicker.Update(msg)
+	om.clusterPicker = updatedPicker.(ui.ProfilePicker)
+	return true, cmd
+}
+
 func (om *OverlayManager) View(background string) string {
 	if !om.IsActive() {
 		return background
@@ -205,6 +529,28 @@ func (om *OverlayManager) View(background string) string {
 		formView = om.produceMessageForm.View()
 	case OverlayDownloadTopic:
 		formView = om.downloadTopicForm.View()
+	case OverlayClusterPicker:
+		formView = om.clusterPicker.View()
+	case OverlayAddPartitions:
+		formView = om.addPartitionsForm.View()
+	case OverlayDeleteGroups:
+		formView = om.deleteGroupsForm.View()
+	case OverlayDeleteOffsets:
+		formView = om.deleteOffsetsForm.View()
+	case OverlayResults:
+		formView = om.resultsForm.View()
+	case OverlayACL:
+		formView = om.aclForm.View()
+	case OverlayDeleteACLs:
+		formView = om.deleteACLsForm.View()
+	case OverlaySCRAMUser:
+		formView = om.scramUserForm.View()
+	case OverlayDeleteSCRAM:
+		formView = om.deleteSCRAMForm.View()
+	case OverlayQuota:
+		formView = om.quotaForm.View()
+	case OverlayDeleteQuota:
+		formView = om.deleteQuotaForm.View()
 	default:
 		return background
 	}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	kafkaadmin "mojosoftware.dev/lazykafka/internal/kafka_admin"
+	"mojosoftware.dev/lazykafka/internal/ui"
+)
+
+type PartitionsLoadedMsg struct {
+	TopicName  string
+	Partitions []ui.PartitionInfo
+	Err        error
+}
+
+func FetchPartitionsCmd(client *kafkaadmin.Client, topicName string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+
+		detail, err := client.DescribeTopic(ctx, topicName)
+		if err != nil {
+			return PartitionsLoadedMsg{TopicName: topicName, Err: err}
+		}
+		startOffsets, endOffsets, err := client.ListOffsets(ctx, topicName)
+		if err != nil {
+			return PartitionsLoadedMsg{TopicName: topicName, Err: err}
+		}
+		stableOffsets, err := client.ListStableOffsets(ctx, topicName)
+		if err != nil {
+			return PartitionsLoadedMsg{TopicName: topicName, Err: err}
+		}
+
+		sorted := detail.Partitions.Sorted()
+		partitions := make([]ui.PartitionInfo, len(sorted))
+		for i, p := range sorted {
+			info := ui.PartitionInfo{
+				Partition:       p.Partition,
+				Leader:          p.Leader,
+				Replicas:        p.Replicas,
+				ISR:             p.ISR,
+				OfflineReplicas: p.OfflineReplicas,
+				Err:             p.Err,
+			}
+			if o, ok := startOffsets.Lookup(topicName, p.Partition); ok && o.Err == nil {
+				info.LogStartOffset = o.Offset
+			}
+			if o, ok := endOffsets.Lookup(topicName, p.Partition); ok && o.Err == nil {
+				info.HighWatermark = o.Offset
+			}
+			if o, ok := stableOffsets.Lookup(topicName, p.Partition); ok && o.Err == nil {
+				info.LastStableOffset = o.Offset
+			}
+			partitions[i] = info
+		}
+		return PartitionsLoadedMsg{TopicName: topicName, Partitions: partitions}
+	}
+}
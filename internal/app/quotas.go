@@ -0,0 +1,81 @@
+package app
+
+import (
+	"context"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/twmb/franz-go/pkg/kadm"
+	kafkaadmin "mojosoftware.dev/lazykafka/internal/kafka_admin"
+	"mojosoftware.dev/lazykafka/internal/ui"
+)
+
+type QuotasLoadedMsg struct {
+	Client *kafkaadmin.Client
+	Quotas []ui.ClientQuota
+	Err    error
+}
+
+type QuotaAlteredMsg struct {
+	Entity string
+	Err    error
+}
+
+func FetchQuotasCmd(client *kafkaadmin.Client) tea.Cmd {
+	return func() tea.Msg {
+		described, err := client.DescribeClientQuotas(context.Background())
+		if err != nil {
+			return QuotasLoadedMsg{Client: client, Err: err}
+		}
+
+		quotas := make([]ui.ClientQuota, len(described))
+		for i, d := range described {
+			q := ui.ClientQuota{Values: make(map[string]float64, len(d.Values))}
+			for _, c := range d.Entity {
+				part := ui.QuotaEntityPart{Type: c.Type, Default: c.Name == nil}
+				if c.Name != nil {
+					part.Name = *c.Name
+				}
+				q.Entity = append(q.Entity, part)
+			}
+			// Keep a stable user, client-id order for display.
+			sort.Slice(q.Entity, func(i, j int) bool { return q.Entity[i].Type > q.Entity[j].Type })
+			for _, v := range d.Values {
+				q.Values[v.Key] = v.Value
+			}
+			quotas[i] = q
+		}
+		sort.Slice(quotas, func(i, j int) bool {
+			return quotas[i].EntityString() < quotas[j].EntityString()
+		})
+		return QuotasLoadedMsg{Client: client, Quotas: quotas}
+	}
+}
+
+// AlterQuotaCmd applies a quota form's changes to its entity in one request.
+func AlterQuotaCmd(client *kafkaadmin.Client, entity []ui.QuotaEntityPart, set map[string]float64, remove []string) tea.Cmd {
+	return func() tea.Msg {
+		var ops []kadm.AlterClientQuotaOp
+		for key, value := range set {
+			ops = append(ops, kadm.AlterClientQuotaOp{Key: key, Value: value})
+		}
+		for _, key := range remove {
+			ops = append(ops, kadm.AlterClientQuotaOp{Key: key, Remove: true})
+		}
+
+		err := client.AlterClientQuotas(context.Background(), quotaEntity(entity), ops)
+		return QuotaAlteredMsg{Entity: ui.ClientQuota{Entity: entity}.EntityString(), Err: err}
+	}
+}
+
+func quotaEntity(parts []ui.QuotaEntityPart) kadm.ClientQuotaEntity {
+	entity := make(kadm.ClientQuotaEntity, len(parts))
+	for i, p := range parts {
+		entity[i] = kadm.ClientQuotaEntityComponent{Type: p.Type}
+		if !p.Default {
+			name := p.Name
+			entity[i].Name = &name
+		}
+	}
+	return entity
+}
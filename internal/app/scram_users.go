@@ -0,0 +1,80 @@
+package app
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/twmb/franz-go/pkg/kadm"
+	kafkaadmin "mojosoftware.dev/lazykafka/internal/kafka_admin"
+	"mojosoftware.dev/lazykafka/internal/ui"
+)
+
+type SCRAMUsersLoadedMsg struct {
+	Client      *kafkaadmin.Client
+	Credentials []ui.SCRAMCredential
+	Err         error
+}
+
+type SCRAMUserAlteredMsg struct {
+	User    string
+	Deleted bool
+	Err     error
+}
+
+func FetchSCRAMUsersCmd(client *kafkaadmin.Client) tea.Cmd {
+	return func() tea.Msg {
+		described, err := client.DescribeSCRAMUsers(context.Background())
+		if err != nil {
+			return SCRAMUsersLoadedMsg{Client: client, Err: err}
+		}
+
+		var credentials []ui.SCRAMCredential
+		for _, d := range described.Sorted() {
+			if d.Err != nil {
+				credentials = append(credentials, ui.SCRAMCredential{User: d.User, Err: d.Err})
+				continue
+			}
+			for _, info := range d.CredInfos {
+				credentials = append(credentials, ui.SCRAMCredential{
+					User:       d.User,
+					Mechanism:  info.Mechanism.String(),
+					Iterations: info.Iterations,
+				})
+			}
+		}
+		return SCRAMUsersLoadedMsg{Client: client, Credentials: credentials}
+	}
+}
+
+// UpsertSCRAMUserCmd sets the submitted password. Errors never include the
+// password, so they are safe to show.
+func UpsertSCRAMUserCmd(client *kafkaadmin.Client, submitted ui.SCRAMUserSubmittedMsg) tea.Cmd {
+	return func() tea.Msg {
+		err := client.UpsertSCRAMUser(context.Background(), kadm.UpsertSCRAM{
+			User:       submitted.User,
+			Mechanism:  scramMechanism(submitted.Mechanism),
+			Iterations: submitted.Iterations,
+			Password:   submitted.Password,
+		})
+		return SCRAMUserAlteredMsg{User: submitted.User, Err: err}
+	}
+}
+
+func DeleteSCRAMCredentialCmd(client *kafkaadmin.Client, user, mechanism string) tea.Cmd {
+	return func() tea.Msg {
+		err := client.DeleteSCRAMCredential(context.Background(), user, scramMechanism(mechanism))
+		return SCRAMUserAlteredMsg{User: user, Deleted: true, Err: err}
+	}
+}
+
+// scramMechanism maps a mechanism name back to its code. Anything else maps
+// to 0, which both kadm and the broker reject.
+func scramMechanism(name string) kadm.ScramMechanism {
+	switch name {
+	case ui.SCRAMSHA256:
+		return kadm.ScramSha256
+	case ui.SCRAMSHA512:
+		return kadm.ScramSha512
+	}
+	return 0
+}
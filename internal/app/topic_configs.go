@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	kafkaadmin "mojosoftware.dev/lazykafka/internal/kafka_admin"
+	"mojosoftware.dev/lazykafka/internal/ui"
+)
+
+type TopicConfigsLoadedMsg struct {
+	TopicName string
+	Entries   []ui.ConfigEntry
+	Err       error
+}
+
+type TopicConfigsAlteredMsg struct {
+	TopicName string
+	Err       error
+}
+
+func FetchTopicConfigsCmd(client *kafkaadmin.Client, topicName string) tea.Cmd {
+	return func() tea.Msg {
+		configs, err := client.DescribeTopicConfigs(context.Background(), topicName)
+		if err != nil {
+			return TopicConfigsLoadedMsg{TopicName: topicName, Err: err}
+		}
+		return TopicConfigsLoadedMsg{TopicName: topicName, Entries: configEntries(configs, kmsg.ConfigSourceDynamicTopicConfig)}
+	}
+}
+
+func AlterTopicConfigsCmd(client *kafkaadmin.Client, topicName string, changes []ui.ConfigChange) tea.Cmd {
+	return func() tea.Msg {
+		err := client.AlterTopicConfigs(context.Background(), topicName, alterConfigs(changes))
+		return TopicConfigsAlteredMsg{TopicName: topicName, Err: err}
+	}
+}
+
+// configEntries converts described configs for display. Only keys whose
+// source is ownSource are set on the resource itself and can be reset.
+func configEntries(configs []kadm.Config, ownSource kmsg.ConfigSource) []ui.ConfigEntry {
+	entries := make([]ui.ConfigEntry, len(configs))
+	for i, c := range configs {
+		entries[i] = ui.ConfigEntry{
+			Key:       c.Key,
+			Value:     c.Value,
+			Source:    configSourceName(c.Source),
+			Sensitive: c.Sensitive,
+			Default:   c.Source != ownSource,
+		}
+	}
+	return entries
+}
+
+// configSourceName turns e.g. DYNAMIC_TOPIC_CONFIG into "dynamic topic".
+func configSourceName(source kmsg.ConfigSource) string {
+	name := strings.TrimSuffix(source.String(), "_CONFIG")
+	return strings.ToLower(strings.ReplaceAll(name, "_", " "))
+}
+
+func alterConfigs(changes []ui.ConfigChange) []kadm.AlterConfig {
+	alters := make([]kadm.AlterConfig, len(changes))
+	for i, c := range changes {
+		alters[i] = kadm.AlterConfig{Op: kadm.SetConfig, Name: c.Key, Value: c.New}
+		if c.New == nil {
+			alters[i].Op = kadm.DeleteConfig
+		}
+	}
+	return alters
+}
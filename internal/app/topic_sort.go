@@ -0,0 +1,98 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+type TopicSort int
+
+const (
+	SortByName TopicSort = iota
+	SortByPartitions
+	SortByReplicationFactor
+	SortByUnderReplicated
+	SortByMessages
+	SortBySize
+	topicSortCount
+)
+
+func (s TopicSort) String() string {
+	switch s {
+	case SortByPartitions:
+		return "partitions"
+	case SortByReplicationFactor:
+		return "replication factor"
+	case SortByUnderReplicated:
+		return "under-replicated"
+	case SortByMessages:
+		return "messages"
+	case SortBySize:
+		return "size"
+	default:
+		return "name"
+	}
+}
+
+func (s TopicSort) Next() TopicSort {
+	return (s + 1) % topicSortCount
+}
+
+// less orders by the sort column, largest first for numeric columns, and
+// falls back to the name so the order is stable across refreshes.
+func (s TopicSort) less(a, b TopicItem) bool {
+	var x, y int64
+	switch s {
+	case SortByPartitions:
+		x, y = int64(a.Partitions), int64(b.Partitions)
+	case SortByReplicationFactor:
+		x, y = int64(a.ReplicationFactor), int64(b.ReplicationFactor)
+	case SortByUnderReplicated:
+		x, y = int64(a.UnderReplicated), int64(b.UnderReplicated)
+	case SortByMessages:
+		x, y = a.Messages, b.Messages
+	case SortBySize:
+		x, y = a.Size, b.Size
+	}
+	if x != y {
+		return x > y
+	}
+	return a.Name < b.Name
+}
+
+// TopicListItems sorts topics and drops internal ones unless showInternal
+// is set.
+func TopicListItems(topics []TopicItem, sortBy TopicSort, showInternal bool) []list.Item {
+	sorted := make([]TopicItem, 0, len(topics))
+	for _, t := range topics {
+		if t.Internal && !showInternal {
+			continue
+		}
+		sorted = append(sorted, t)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sortBy.less(sorted[i], sorted[j])
+	})
+
+	items := make([]list.Item, len(sorted))
+	for i, t := range sorted {
+		items[i] = t
+	}
+	return items
+}
+
+// formatCount abbreviates large counts, e.g. 1234567 becomes 1.2M.
+func formatCount(n int64) string {
+	switch {
+	case n >= 1_000_000_000:
+		return fmt.Sprintf("%.1fB", float64(n)/1_000_000_000)
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
+	case n >= 1_000:
+		return fmt.Sprintf("%.1fk", float64(n)/1_000)
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
@@ -0,0 +1,195 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type TLSConfig struct {
+	Enabled            bool   `yaml:"enabled"`
+	CAFile             string `yaml:"ca_file"`
+	CertFile           string `yaml:"cert_file"`
+	KeyFile            string `yaml:"key_file"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+}
+
+type SASLConfig struct {
+	Mechanism string `yaml:"mechanism"`
+	Username  string `yaml:"username"`
+	Password  string `yaml:"password"`
+	Token     string `yaml:"token"`
+}
+
+type SchemaRegistryConfig struct {
+	URL      string `yaml:"url"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+type ProtobufConfig struct {
+	ImportPaths    []string          `yaml:"import_paths"`
+	Files          []string          `yaml:"files"`
+	DescriptorSets []string          `yaml:"descriptor_sets"`
+	Topics         map[string]string `yaml:"topics"`
+}
+
+type Profile struct {
+	Name           string               `yaml:"name"`
+	Brokers        []string             `yaml:"brokers"`
+	ClientID       string               `yaml:"client_id"`
+	TLS            TLSConfig            `yaml:"tls"`
+	SASL           SASLConfig           `yaml:"sasl"`
+	SchemaRegistry SchemaRegistryConfig `yaml:"schema_registry"`
+	Protobuf       ProtobufConfig       `yaml:"protobuf"`
+	KeySerde       string               `yaml:"key_serde"`
+	ValueSerde     string               `yaml:"value_serde"`
+	ReadOnly       bool                 `yaml:"read_only"`
+}
+
+type Config struct {
+	Path           string    `yaml:"-"`
+	DefaultProfile string    `yaml:"default_profile"`
+	Profiles       []Profile `yaml:"profiles"`
+}
+
+var ErrNoConfig = errors.New("no config file found")
+
+// DefaultPath returns $XDG_CONFIG_HOME/lazykafka/config.yaml, falling back
+// to ~/.config/lazykafka/config.yaml.
+func DefaultPath() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "lazykafka", "config.yaml"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to locate home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "lazykafka", "config.yaml"), nil
+}
+
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w at %s", ErrNoConfig, path)
+		}
+		return nil, fmt.Errorf("unable to read config: %w", err)
+	}
+
+	cfg, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	cfg.Path = path
+	return cfg, nil
+}
+
+func Parse(data []byte) (*Config, error) {
+	var cfg Config
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("malformed config: %w", err)
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	for i := range cfg.Profiles {
+		tls := &cfg.Profiles[i].TLS
+		tls.CAFile = expandHome(tls.CAFile)
+		tls.CertFile = expandHome(tls.CertFile)
+		tls.KeyFile = expandHome(tls.KeyFile)
+
+		protobuf := &cfg.Profiles[i].Protobuf
+		for j := range protobuf.ImportPaths {
+			protobuf.ImportPaths[j] = expandHome(protobuf.ImportPaths[j])
+		}
+		for j := range protobuf.DescriptorSets {
+			protobuf.DescriptorSets[j] = expandHome(protobuf.DescriptorSets[j])
+		}
+	}
+	return &cfg, nil
+}
+
+func (c *Config) validate() error {
+	seen := make(map[string]bool, len(c.Profiles))
+	for i, p := range c.Profiles {
+		if p.Name == "" {
+			return fmt.Errorf("profile #%d has no name", i+1)
+		}
+		if seen[p.Name] {
+			return fmt.Errorf("profile %q is defined more than once", p.Name)
+		}
+		seen[p.Name] = true
+
+		if len(p.Brokers) == 0 {
+			return fmt.Errorf("profile %q has no brokers", p.Name)
+		}
+		if url := p.SchemaRegistry.URL; url != "" && !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+			return fmt.Errorf("profile %q: schema_registry url must start with http:// or https://", p.Name)
+		}
+		if len(p.Protobuf.Topics) > 0 && len(p.Protobuf.Files) == 0 && len(p.Protobuf.DescriptorSets) == 0 {
+			return fmt.Errorf("profile %q: protobuf topics need files or descriptor_sets to find their types in", p.Name)
+		}
+	}
+
+	if c.DefaultProfile != "" && !seen[c.DefaultProfile] {
+		return fmt.Errorf("default_profile %q does not match any profile", c.DefaultProfile)
+	}
+	return nil
+}
+
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
+
+func (c *Config) Profile(name string) (Profile, error) {
+	for _, p := range c.Profiles {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	if len(c.Profiles) == 0 {
+		return Profile{}, fmt.Errorf("profile %q not found: %s defines no profiles", name, c.Path)
+	}
+	return Profile{}, fmt.Errorf("profile %q not found (available: %s)", name, strings.Join(c.ProfileNames(), ", "))
+}
+
+func (c *Config) ProfileNames() []string {
+	names := make([]string, len(c.Profiles))
+	for i, p := range c.Profiles {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// AdHocProfile builds a profile from a comma separated bootstrap string, as
+// passed on the command line without a config file.
+func AdHocProfile(bootstrapServers string) Profile {
+	var brokers []string
+	for _, b := range strings.Split(bootstrapServers, ",") {
+		if b = strings.TrimSpace(b); b != "" {
+			brokers = append(brokers, b)
+		}
+	}
+	return Profile{
+		Name:    bootstrapServers,
+		Brokers: brokers,
+	}
+}
@@ -0,0 +1,78 @@
+package kafkaadmin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+)
+
+// DescribeACLs lists the bindings matching the builder's filters.
+func (c *Client) DescribeACLs(ctx context.Context, b *kadm.ACLBuilder) (kadm.DescribedACLs, error) {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	results, err := c.admClient.DescribeACLs(ctx, b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe ACLs: %w", err)
+	}
+	var described kadm.DescribedACLs
+	for _, r := range results {
+		if r.Err != nil {
+			return nil, fmt.Errorf("failed to describe ACLs: %w", withErrMessage(r.Err, r.ErrMessage))
+		}
+		described = append(described, r.Described...)
+	}
+	return described, nil
+}
+
+// CreateACLs creates every binding the builder expands to. Each binding
+// succeeds or fails on its own, with the broker's message folded into Err.
+func (c *Client) CreateACLs(ctx context.Context, b *kadm.ACLBuilder) (kadm.CreateACLsResults, error) {
+	if c.opts.ReadOnly {
+		return nil, ErrReadOnly
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	results, err := c.admClient.CreateACLs(ctx, b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ACLs: %w", err)
+	}
+	for i, r := range results {
+		if r.Err != nil {
+			results[i].Err = withErrMessage(r.Err, r.ErrMessage)
+		}
+	}
+	return results, nil
+}
+
+// DeleteACLs deletes every binding matching the builder's filters. Deleting
+// works on filters, so describe with the same builder first to see what
+// would go.
+func (c *Client) DeleteACLs(ctx context.Context, b *kadm.ACLBuilder) (kadm.DeleteACLsResults, error) {
+	if c.opts.ReadOnly {
+		return nil, ErrReadOnly
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	results, err := c.admClient.DeleteACLs(ctx, b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete ACLs: %w", err)
+	}
+	for i, r := range results {
+		if r.Err != nil {
+			results[i].Err = withErrMessage(r.Err, r.ErrMessage)
+		}
+		for j, d := range r.Deleted {
+			if d.Err != nil {
+				results[i].Deleted[j].Err = withErrMessage(d.Err, d.ErrMessage)
+			}
+		}
+	}
+	return results, nil
+}
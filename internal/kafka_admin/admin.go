@@ -3,6 +3,7 @@ package kafkaadmin
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
@@ -12,41 +13,73 @@ import (
 	"github.com/twmb/franz-go/pkg/kadm"
 	"github.com/twmb/franz-go/pkg/kgo"
 	"github.com/twmb/franz-go/pkg/kversion"
+	"mojosoftware.dev/lazykafka/internal/serde"
 )
 
+type Options struct {
+	Brokers  []string
+	ClientID string
+	TLS      TLSOptions
+	SASL     SASLOptions
+	ReadOnly bool
+}
+
 type Client struct {
-	kgoClient  *kgo.Client
-	admClient  *kadm.Client
-	bootstraps string
+	kgoClient *kgo.Client
+	admClient *kadm.Client
+	opts      Options
+	connOpts  []kgo.Opt
 }
 
+var ErrReadOnly = errors.New("cluster profile is read-only")
+
 func die(msg string, args ...any) {
 	fmt.Fprintf(os.Stderr, msg, args...)
 	os.Exit(1)
 }
 
-func NewClient(bootstrapServers string) (*Client, error) {
-	client, err := kgo.NewClient(
-		kgo.SeedBrokers(bootstrapServers),
-		kgo.MaxVersions(kversion.V2_4_0()),
-	)
+func NewClient(opts Options) (*Client, error) {
+	if len(opts.Brokers) == 0 {
+		return nil, errors.New("no brokers configured")
+	}
+
+	connOpts, err := opts.connectionOpts()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		opts:     opts,
+		connOpts: connOpts,
+	}
+
+	// 2.7 is the oldest release with the SCRAM credential APIs.
+	client, err := kgo.NewClient(c.clientOpts(kgo.MaxVersions(kversion.V2_7_0()))...)
 	if err != nil {
 		return nil, err
 	}
 
-	adminClient := kadm.NewClient(client)
+	c.kgoClient = client
+	c.admClient = kadm.NewClient(client)
+	return c, nil
+}
 
-	return &Client{
-		kgoClient:  client,
-		admClient:  adminClient,
-		bootstraps: bootstrapServers,
-	}, nil
+// clientOpts appends extra to the shared connection options without
+// touching the shared slice.
+func (c *Client) clientOpts(extra ...kgo.Opt) []kgo.Opt {
+	opts := make([]kgo.Opt, 0, len(c.connOpts)+len(extra))
+	opts = append(opts, c.connOpts...)
+	return append(opts, extra...)
+}
+
+func (c *Client) ReadOnly() bool {
+	return c.opts.ReadOnly
 }
 
 func (c *Client) ListTopics(ctx context.Context) (kadm.TopicDetails, error) {
 	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
-	topicDetails, err := c.admClient.ListTopics(ctx)
+	topicDetails, err := c.admClient.ListTopicsWithInternal(ctx)
 	if err != nil {
 		log.Errorf("failed to list topics: %v", err)
 		return nil, err
@@ -54,7 +87,83 @@ func (c *Client) ListTopics(ctx context.Context) (kadm.TopicDetails, error) {
 	return topicDetails, nil
 }
 
-func (c *Client) CreateTopic(ctx context.Context, topicName string) (kadm.CreateTopicResponse, error) {
+func (c *Client) DescribeTopic(ctx context.Context, topicName string) (kadm.TopicDetail, error) {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	topicDetails, err := c.admClient.ListTopicsWithInternal(ctx, topicName)
+	if err != nil {
+		return kadm.TopicDetail{}, err
+	}
+	detail, ok := topicDetails[topicName]
+	if !ok {
+		return kadm.TopicDetail{}, fmt.Errorf("topic %q not found", topicName)
+	}
+	if detail.Err != nil {
+		return kadm.TopicDetail{}, detail.Err
+	}
+	return detail, nil
+}
+
+// ListStableOffsets returns the last stable offset of every partition of
+// the given topics, i.e. the end offset as seen by read_committed consumers.
+func (c *Client) ListStableOffsets(ctx context.Context, topics ...string) (kadm.ListedOffsets, error) {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	return c.admClient.ListCommittedOffsets(ctx, topics...)
+}
+
+// ListOffsets returns the log start and end offsets of every partition of
+// the given topics. Partitions that failed carry their error in Err.
+func (c *Client) ListOffsets(ctx context.Context, topics ...string) (kadm.ListedOffsets, kadm.ListedOffsets, error) {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	startOffsets, err := c.admClient.ListStartOffsets(ctx, topics...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list start offsets: %w", err)
+	}
+	endOffsets, err := c.admClient.ListEndOffsets(ctx, topics...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list end offsets: %w", err)
+	}
+	return startOffsets, endOffsets, nil
+}
+
+const maxTopicNameLength = 249
+
+// ValidateTopicName applies the broker's topic naming rules so bad names
+// are rejected before a request is sent.
+func ValidateTopicName(name string) error {
+	if name == "" {
+		return errors.New("topic name must not be empty")
+	}
+	if name == "." || name == ".." {
+		return fmt.Errorf("topic name cannot be %q", name)
+	}
+	if len(name) > maxTopicNameLength {
+		return fmt.Errorf("topic name is %d characters, the maximum is %d", len(name), maxTopicNameLength)
+	}
+	for _, r := range name {
+		valid := r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '_' || r == '-'
+		if !valid {
+			return fmt.Errorf("topic name contains %q; only ASCII letters, digits, '.', '_' and '-' are allowed", r)
+		}
+	}
+	return nil
+}
+
+// CreateTopic creates a topic. A partition count or replication factor of
+// -1 uses the broker default.
+func (c *Client) CreateTopic(ctx context.Context, topicName string, partitions int32, replicationFactor int16, configs map[string]*string) (kadm.CreateTopicResponse, error) {
+	if c.opts.ReadOnly {
+		return kadm.CreateTopicResponse{}, ErrReadOnly
+	}
+	if err := ValidateTopicName(topicName); err != nil {
+		return kadm.CreateTopicResponse{}, err
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
 
@@ -65,21 +174,24 @@ func (c *Client) CreateTopic(ctx context.Context, topicName string) (kadm.Create
 	}
 
 	if topicDetails.Has(topicName) {
-		log.Infof("topic %v already exists\n", topicName)
-		return kadm.CreateTopicResponse{}, err
+		return kadm.CreateTopicResponse{}, fmt.Errorf("topic %q already exists", topicName)
 	}
 
 	log.Debugf("creating %v topic\n", topicName)
-	createTopicResponse, err := c.admClient.CreateTopic(ctx, -1, -1, nil, topicName)
+	createTopicResponse, err := c.admClient.CreateTopic(ctx, partitions, replicationFactor, configs, topicName)
 	if err != nil {
 		log.Errorf("failed to create topic: %v", err)
-		return kadm.CreateTopicResponse{}, err
+		return kadm.CreateTopicResponse{}, withErrMessage(err, createTopicResponse.ErrMessage)
 	}
 	log.Debugf("Successfully created topic %v\n", createTopicResponse.Topic)
 	return createTopicResponse, nil
 }
 
 func (c *Client) DeleteTopic(ctx context.Context, topicName string) (kadm.DeleteTopicResponse, error) {
+	if c.opts.ReadOnly {
+		return kadm.DeleteTopicResponse{}, ErrReadOnly
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
 
@@ -90,44 +202,84 @@ func (c *Client) DeleteTopic(ctx context.Context, topicName string) (kadm.Delete
 	return deleteTopicResponse, nil
 }
 
-func (c *Client) BuildRecord(topicName string, partitionNumber string, keySerde string, valueSerde string, key string, value string, headers string) (kgo.Record, error) {
-	if value == "" {
-		log.Errorf("Value must not be null")
+// SetPartitionCount grows a topic to total partitions. Kafka cannot remove
+// partitions, so total must exceed the current count.
+func (c *Client) SetPartitionCount(ctx context.Context, topicName string, total int) error {
+	if c.opts.ReadOnly {
+		return ErrReadOnly
 	}
 
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	responses, err := c.admClient.UpdatePartitions(ctx, total, topicName)
+	if err != nil {
+		return err
+	}
+	resp, ok := responses[topicName]
+	if !ok {
+		return fmt.Errorf("topic %q missing from create partitions response", topicName)
+	}
+	if resp.Err != nil {
+		return withErrMessage(resp.Err, resp.ErrMessage)
+	}
+	return nil
+}
+
+// BuildRecord encodes the key and value text typed into the produce form
+// with the chosen serializers. Text that doesn't suit its serializer is
+// returned as an error, rather than produced as a record nobody can read.
+func (c *Client) BuildRecord(topicName string, partitionNumber string, keySerde, valueSerde serde.Serializer, key string, value string, headers string) (kgo.Record, error) {
 	partitionNumberInt, err := strconv.Atoi(partitionNumber)
+	if err != nil {
+		return kgo.Record{}, fmt.Errorf("partition must be a number, got %q", partitionNumber)
+	}
+
+	keyBytes, err := serde.Encode(keySerde, topicName, serde.KeyPart, key)
+	if err != nil {
+		return kgo.Record{}, err
+	}
+	valueBytes, err := serde.Encode(valueSerde, topicName, serde.ValuePart, value)
 	if err != nil {
 		return kgo.Record{}, err
 	}
 
 	r := kgo.Record{
 		Partition: int32(partitionNumberInt),
-		Key:       []byte(key),
-		Value:     []byte(value),
+		Key:       keyBytes,
+		Value:     valueBytes,
 		Topic:     topicName,
 	}
 	return r, nil
 
 }
 
-func (c *Client) ProduceMessage(ctx context.Context, record *kgo.Record) {
-	ctx = context.Background()
-	c.kgoClient.Produce(ctx, record, func(r *kgo.Record, err error) {
-		if err != nil {
-			log.Errorf("Error producing message: %v", err)
-		}
-	})
-	c.kgoClient.Flush(ctx)
-}
+func (c *Client) ProduceMessage(ctx context.Context, record *kgo.Record) error {
+	if c.opts.ReadOnly {
+		return ErrReadOnly
+	}
 
-func (c *Client) ConsumeMessages(ctx context.Context, topicName string, recordChan chan<- *kgo.Record) error {
-	opts := []kgo.Opt{
-		kgo.ConsumeTopics(topicName),
-		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
-		kgo.SeedBrokers(c.bootstraps),
+	if err := c.kgoClient.ProduceSync(ctx, record).FirstErr(); err != nil {
+		log.Errorf("Error producing message: %v", err)
+		return err
 	}
+	return nil
+}
 
-	cl, err := kgo.NewClient(opts...)
+// ConsumeMessages streams the topic into recordChan until ctx is done. Every
+// partition starts at start, unless partitions is non-nil, in which case
+// only the partitions in it are read, each from its own offset. Offsets past
+// either end of a partition start at that end.
+func (c *Client) ConsumeMessages(ctx context.Context, topicName string, start kgo.Offset, partitions map[int32]kgo.Offset, recordChan chan<- *kgo.Record) error {
+	consume := kgo.ConsumeTopics(topicName)
+	if partitions != nil {
+		consume = kgo.ConsumePartitions(map[string]map[int32]kgo.Offset{topicName: partitions})
+	}
+	cl, err := kgo.NewClient(c.clientOpts(
+		consume,
+		kgo.ConsumeStartOffset(start),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
+	)...)
 	if err != nil {
 		return fmt.Errorf("unable to create client: %w", err)
 	}
@@ -161,14 +313,14 @@ func (c *Client) ConsumeMessages(ctx context.Context, topicName string, recordCh
 	}
 }
 
-func (c *Client) DownloadTopic(ctx context.Context, topicName string, filePath string) error {
-	opts := []kgo.Opt{
+// DownloadTopic writes the topic's records to filePath as a JSON array, with
+// keys and values read by the given deserializers. A key or value that
+// fails to decode is written as null, with the error alongside.
+func (c *Client) DownloadTopic(ctx context.Context, topicName string, filePath string, key, value serde.Deserializer) error {
+	cl, err := kgo.NewClient(c.clientOpts(
 		kgo.ConsumeTopics(topicName),
 		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
-		kgo.SeedBrokers(c.bootstraps),
-	}
-
-	cl, err := kgo.NewClient(opts...)
+	)...)
 	if err != nil {
 		return fmt.Errorf("unable to create client: %w", err)
 	}
@@ -224,8 +376,23 @@ func (c *Client) DownloadTopic(ctx context.Context, topicName string, filePath s
 				"partition": record.Partition,
 				"offset":    record.Offset,
 				"timestamp": record.Timestamp.Format(time.RFC3339),
-				"key":       string(record.Key),
-				"value":     string(record.Value),
+			}
+			for _, field := range []struct {
+				name string
+				d    serde.Deserializer
+				data []byte
+			}{{"key", key, record.Key}, {"value", value, record.Value}} {
+				if field.data == nil {
+					recordData[field.name] = nil
+					continue
+				}
+				text, err := serde.Decode(field.d, topicName, field.data)
+				if err != nil {
+					recordData[field.name] = nil
+					recordData[field.name+"_error"] = err.Error()
+					continue
+				}
+				recordData[field.name] = text
 			}
 
 			encoder.Encode(recordData)
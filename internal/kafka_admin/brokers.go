@@ -0,0 +1,36 @@
+package kafkaadmin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+)
+
+// Metadata returns the cluster ID, controller, brokers and the layout of
+// every topic, internal ones included.
+func (c *Client) Metadata(ctx context.Context) (kadm.Metadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	metadata, err := c.admClient.Metadata(ctx)
+	if err != nil {
+		return kadm.Metadata{}, fmt.Errorf("failed to fetch metadata: %w", err)
+	}
+	return metadata, nil
+}
+
+// DescribeLogDirs describes every log dir on every broker, including the size
+// and offset lag of each replica. When some brokers fail to respond the
+// results from the rest are still returned alongside the error.
+func (c *Client) DescribeLogDirs(ctx context.Context) (kadm.DescribedAllLogDirs, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	dirs, err := c.admClient.DescribeAllLogDirs(ctx, nil)
+	if err != nil {
+		return dirs, fmt.Errorf("failed to describe log dirs: %w", err)
+	}
+	return dirs, nil
+}
@@ -0,0 +1,114 @@
+package kafkaadmin
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+)
+
+func (c *Client) DescribeTopicConfigs(ctx context.Context, topicName string) ([]kadm.Config, error) {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	resourceConfigs, err := c.admClient.DescribeTopicConfigs(ctx, topicName)
+	if err != nil {
+		return nil, err
+	}
+	rc, err := resourceConfigs.On(topicName, nil)
+	if err != nil {
+		return nil, err
+	}
+	if rc.Err != nil {
+		return nil, withErrMessage(rc.Err, rc.ErrMessage)
+	}
+	return rc.Configs, nil
+}
+
+// AlterTopicConfigs applies incremental changes: SetConfig to change a key,
+// DeleteConfig to reset it to the broker default.
+func (c *Client) AlterTopicConfigs(ctx context.Context, topicName string, changes []kadm.AlterConfig) error {
+	if c.opts.ReadOnly {
+		return ErrReadOnly
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	responses, err := c.admClient.AlterTopicConfigs(ctx, changes, topicName)
+	if err != nil {
+		return err
+	}
+	return alterConfigsError(responses)
+}
+
+// AllBrokers addresses the cluster-wide dynamic broker defaults rather than
+// a single broker.
+const AllBrokers int32 = -1
+
+func brokerResource(broker int32) (name string, brokers []int32) {
+	if broker == AllBrokers {
+		return "", nil
+	}
+	return strconv.Itoa(int(broker)), []int32{broker}
+}
+
+// DescribeBrokerConfigs describes one broker's configs, or with AllBrokers
+// the cluster-wide defaults, which only lists keys that have been set.
+func (c *Client) DescribeBrokerConfigs(ctx context.Context, broker int32) ([]kadm.Config, error) {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	name, brokers := brokerResource(broker)
+	resourceConfigs, err := c.admClient.DescribeBrokerConfigs(ctx, brokers...)
+	if err != nil {
+		return nil, err
+	}
+	rc, err := resourceConfigs.On(name, nil)
+	if err != nil {
+		return nil, err
+	}
+	if rc.Err != nil {
+		return nil, withErrMessage(rc.Err, rc.ErrMessage)
+	}
+	return rc.Configs, nil
+}
+
+// AlterBrokerConfigs incrementally changes dynamic configs on one broker, or
+// with AllBrokers the cluster-wide defaults. Static configs are rejected by
+// the broker.
+func (c *Client) AlterBrokerConfigs(ctx context.Context, broker int32, changes []kadm.AlterConfig) error {
+	if c.opts.ReadOnly {
+		return ErrReadOnly
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	_, brokers := brokerResource(broker)
+	responses, err := c.admClient.AlterBrokerConfigs(ctx, changes, brokers...)
+	if err != nil {
+		return err
+	}
+	return alterConfigsError(responses)
+}
+
+func alterConfigsError(responses kadm.AlterConfigsResponses) error {
+	for _, resp := range responses {
+		if resp.Err != nil {
+			return fmt.Errorf("%s: %w", resp.Name, withErrMessage(resp.Err, resp.ErrMessage))
+		}
+	}
+	return nil
+}
+
+// withErrMessage appends the broker's extra error detail, which is usually
+// more useful than the error code alone.
+func withErrMessage(err error, message string) error {
+	if message == "" || message == err.Error() {
+		return err
+	}
+	return fmt.Errorf("%w: %s", err, message)
+}
@@ -0,0 +1,130 @@
+package kafkaadmin
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl"
+	"github.com/twmb/franz-go/pkg/sasl/oauth"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+)
+
+type TLSOptions struct {
+	Enabled            bool
+	CAFile             string
+	CertFile           string
+	KeyFile            string
+	InsecureSkipVerify bool
+}
+
+type SASLOptions struct {
+	Mechanism string
+	Username  string
+	Password  string
+	Token     string
+}
+
+const (
+	MechanismPlain       = "PLAIN"
+	MechanismScramSha256 = "SCRAM-SHA-256"
+	MechanismScramSha512 = "SCRAM-SHA-512"
+	MechanismOAuthBearer = "OAUTHBEARER"
+)
+
+func (t TLSOptions) enabled() bool {
+	return t.Enabled || t.CAFile != "" || t.CertFile != "" || t.KeyFile != ""
+}
+
+func (t TLSOptions) config() (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: t.InsecureSkipVerify,
+	}
+
+	if t.CAFile != "" {
+		pem, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificates found in %s", t.CAFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if (t.CertFile == "") != (t.KeyFile == "") {
+		return nil, errors.New("TLS cert file and key file must be set together")
+	}
+	if t.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+func (s SASLOptions) mechanism() (sasl.Mechanism, error) {
+	switch strings.ToUpper(s.Mechanism) {
+	case MechanismPlain:
+		return plain.Auth{User: s.Username, Pass: s.Password}.AsMechanism(), nil
+	case MechanismScramSha256:
+		return scram.Auth{User: s.Username, Pass: s.Password}.AsSha256Mechanism(), nil
+	case MechanismScramSha512:
+		return scram.Auth{User: s.Username, Pass: s.Password}.AsSha512Mechanism(), nil
+	case MechanismOAuthBearer:
+		if s.Token == "" {
+			return nil, errors.New("OAUTHBEARER requires a token")
+		}
+		return oauth.Oauth(func(context.Context) (oauth.Auth, error) {
+			return oauth.Auth{Token: s.Token}, nil
+		}), nil
+	default:
+		return nil, fmt.Errorf("unsupported SASL mechanism %q (expected %s, %s, %s or %s)",
+			s.Mechanism, MechanismPlain, MechanismScramSha256, MechanismScramSha512, MechanismOAuthBearer)
+	}
+}
+
+// connectionOpts returns the options shared by every kgo.Client created for
+// this cluster: seeds, client ID and the TLS / SASL settings.
+func (o Options) connectionOpts() ([]kgo.Opt, error) {
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(o.Brokers...),
+		kgo.ClientID(o.clientID()),
+	}
+
+	if o.TLS.enabled() {
+		tlsCfg, err := o.TLS.config()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, kgo.DialTLSConfig(tlsCfg))
+	}
+
+	if o.SASL.Mechanism != "" {
+		mechanism, err := o.SASL.mechanism()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, kgo.SASL(mechanism))
+	}
+
+	return opts, nil
+}
+
+func (o Options) clientID() string {
+	if o.ClientID == "" {
+		return "lazykafka"
+	}
+	return o.ClientID
+}
@@ -0,0 +1,210 @@
+package kafkaadmin
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl"
+	"mojosoftware.dev/lazykafka/internal/serde"
+)
+
+const testTopic = "orders"
+
+// newSASLCluster starts a cluster with one SASL user and returns the options
+// to reach it as that user.
+func newSASLCluster(t *testing.T, mechanism string) Options {
+	t.Helper()
+
+	cluster, err := kfake.NewCluster(
+		kfake.NumBrokers(1),
+		kfake.EnableSASL(),
+		kfake.Superuser(mechanism, "alice", "secret"),
+		kfake.SeedTopics(1, testTopic),
+	)
+	if err != nil {
+		t.Fatalf("unable to start cluster: %v", err)
+	}
+	t.Cleanup(cluster.Close)
+
+	return Options{
+		Brokers: cluster.ListenAddrs(),
+		SASL: SASLOptions{
+			Mechanism: mechanism,
+			Username:  "alice",
+			Password:  "secret",
+		},
+	}
+}
+
+func TestSASLAuthentication(t *testing.T) {
+	for _, mechanism := range []string{MechanismPlain, MechanismScramSha256, MechanismScramSha512} {
+		t.Run(mechanism, func(t *testing.T) {
+			t.Parallel()
+
+			client, err := NewClient(newSASLCluster(t, mechanism))
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			defer client.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			topics, err := client.ListTopics(ctx)
+			if err != nil {
+				t.Fatalf("ListTopics: %v", err)
+			}
+			if _, ok := topics[testTopic]; !ok {
+				t.Fatalf("ListTopics: %s missing from %v", testTopic, topics.Names())
+			}
+
+			record := kgo.StringRecord("hello")
+			record.Topic = testTopic
+			if err := client.ProduceMessage(ctx, record); err != nil {
+				t.Fatalf("ProduceMessage: %v", err)
+			}
+
+			// The consumer and download clients are separate connections,
+			// built from the same options.
+			records := make(chan *kgo.Record, 1)
+			consumeCtx, stopConsuming := context.WithCancel(ctx)
+			defer stopConsuming()
+			go client.ConsumeMessages(consumeCtx, testTopic, kgo.NewOffset().AtStart(), nil, records)
+			select {
+			case r := <-records:
+				if string(r.Value) != "hello" {
+					t.Errorf("ConsumeMessages: got value %q, want %q", r.Value, "hello")
+				}
+			case <-ctx.Done():
+				t.Fatal("ConsumeMessages: no record before the timeout")
+			}
+			stopConsuming()
+
+			path := filepath.Join(t.TempDir(), "orders.json")
+			downloadCtx, stopDownloading := context.WithTimeout(ctx, 2*time.Second)
+			defer stopDownloading()
+			text := serde.NewRegistry().Get("string")
+			if err := client.DownloadTopic(downloadCtx, testTopic, path, text, text); err != nil {
+				t.Fatalf("DownloadTopic: %v", err)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var downloaded []struct {
+				Value string `json:"value"`
+			}
+			if err := json.Unmarshal(data, &downloaded); err != nil {
+				t.Fatalf("DownloadTopic wrote invalid JSON: %v\n%s", err, data)
+			}
+			if len(downloaded) != 1 || downloaded[0].Value != "hello" {
+				t.Errorf("DownloadTopic: got %s, want the one record produced", data)
+			}
+		})
+	}
+}
+
+func TestSASLWrongPassword(t *testing.T) {
+	opts := newSASLCluster(t, MechanismScramSha256)
+	opts.SASL.Password = "wrong"
+
+	client, err := NewClient(opts)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	// The broker drops the connection, which kgo retries until the deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if _, err := client.ListTopics(ctx); err == nil {
+		t.Fatal("ListTopics: expected an authentication error")
+	}
+}
+
+func TestConnectionOptsSASL(t *testing.T) {
+	tests := []struct {
+		name    string
+		sasl    SASLOptions
+		want    string // mechanism name, empty for none
+		wantErr string
+	}{
+		{name: "none"},
+		{name: "plain", sasl: SASLOptions{Mechanism: "plain", Username: "u", Password: "p"}, want: MechanismPlain},
+		{name: "oauthbearer", sasl: SASLOptions{Mechanism: MechanismOAuthBearer, Token: "t0ken"}, want: MechanismOAuthBearer},
+		{name: "oauthbearer without token", sasl: SASLOptions{Mechanism: MechanismOAuthBearer}, wantErr: "requires a token"},
+		{name: "unsupported", sasl: SASLOptions{Mechanism: "GSSAPI"}, wantErr: "unsupported SASL mechanism"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := Options{Brokers: []string{"localhost:9092"}, SASL: tt.sasl}.connectionOpts()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("connectionOpts: got error %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("connectionOpts: %v", err)
+			}
+
+			// The client only connects once it is used.
+			client, err := kgo.NewClient(opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer client.Close()
+			mechanisms, _ := client.OptValue(kgo.SASL).([]sasl.Mechanism)
+			var got string
+			if len(mechanisms) > 0 {
+				got = mechanisms[0].Name()
+			}
+			if got != tt.want {
+				t.Errorf("SASL mechanism: got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConnectionOptsTLSFiles(t *testing.T) {
+	dir := t.TempDir()
+	notPEM := filepath.Join(dir, "not.pem")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "missing.pem")
+
+	tests := []struct {
+		name    string
+		tls     TLSOptions
+		wantErr string
+	}{
+		{name: "enabled", tls: TLSOptions{Enabled: true}},
+		{name: "missing CA", tls: TLSOptions{CAFile: missing}, wantErr: "unable to read CA file"},
+		{name: "CA without certificates", tls: TLSOptions{CAFile: notPEM}, wantErr: "no PEM certificates"},
+		{name: "cert without key", tls: TLSOptions{CertFile: notPEM}, wantErr: "must be set together"},
+		{name: "key without cert", tls: TLSOptions{KeyFile: notPEM}, wantErr: "must be set together"},
+		{name: "invalid key pair", tls: TLSOptions{CertFile: notPEM, KeyFile: notPEM}, wantErr: "unable to load client certificate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Options{Brokers: []string{"localhost:9092"}, TLS: tt.tls}.connectionOpts()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("connectionOpts: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("connectionOpts: got error %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package kafkaadmin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+)
+
+var ErrGroupActive = errors.New("consumer group has active members")
+
+// GroupLags describes the given groups, or every group when none are given,
+// along with their committed offsets and lag.
+func (c *Client) GroupLags(ctx context.Context, groups ...string) (kadm.DescribedGroupLags, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	return c.admClient.Lag(ctx, groups...)
+}
+
+// CheckGroupInactive returns ErrGroupActive when the group has members, since
+// the coordinator rejects offset commits from outside a live generation.
+func (c *Client) CheckGroupInactive(ctx context.Context, group string) error {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	described, err := c.admClient.DescribeGroups(ctx, group)
+	if err != nil {
+		return fmt.Errorf("failed to describe group: %w", err)
+	}
+	g, ok := described[group]
+	if !ok {
+		return fmt.Errorf("group %q not found", group)
+	}
+	if g.Err != nil {
+		return fmt.Errorf("failed to describe group: %w", g.Err)
+	}
+	if len(g.Members) > 0 {
+		return fmt.Errorf("%w: %s has %d (state %s); stop its consumers first", ErrGroupActive, group, len(g.Members), g.State)
+	}
+	return nil
+}
+
+// CommittedOffsets fetches every offset the group has committed.
+func (c *Client) CommittedOffsets(ctx context.Context, group string) (kadm.OffsetResponses, error) {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	offsets, err := c.admClient.FetchOffsets(ctx, group)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch committed offsets: %w", err)
+	}
+	return offsets, nil
+}
+
+// ListOffsetsAfter returns the first offset at or after t for each partition,
+// or the end offset when nothing has been written since.
+func (c *Client) ListOffsetsAfter(ctx context.Context, t time.Time, topics ...string) (kadm.ListedOffsets, error) {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	offsets, err := c.admClient.ListOffsetsAfterMilli(ctx, t.UnixMilli(), topics...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list offsets by timestamp: %w", err)
+	}
+	return offsets, nil
+}
+
+// CommitGroupOffsets overwrites the group's committed offsets. The group must
+// be inactive.
+func (c *Client) CommitGroupOffsets(ctx context.Context, group string, offsets kadm.Offsets) error {
+	if c.opts.ReadOnly {
+		return ErrReadOnly
+	}
+	if err := c.CheckGroupInactive(ctx, group); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	responses, err := c.admClient.CommitOffsets(ctx, group, offsets)
+	if err != nil {
+		return fmt.Errorf("failed to commit offsets: %w", err)
+	}
+	return responses.Error()
+}
+
+// DeleteGroups deletes the given groups. Each group succeeds or fails on its
+// own, so callers should check every response.
+func (c *Client) DeleteGroups(ctx context.Context, groups ...string) (kadm.DeleteGroupResponses, error) {
+	if c.opts.ReadOnly {
+		return nil, ErrReadOnly
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	responses, err := c.admClient.DeleteGroups(ctx, groups...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete groups: %w", err)
+	}
+	return responses, nil
+}
+
+// DeleteOffsets removes the group's committed offsets for the given
+// partitions. Each partition succeeds or fails on its own.
+func (c *Client) DeleteOffsets(ctx context.Context, group string, partitions map[string][]int32) (kadm.DeleteOffsetsResponses, error) {
+	if c.opts.ReadOnly {
+		return nil, ErrReadOnly
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	set := make(kadm.TopicsSet)
+	for topic, ps := range partitions {
+		set.Add(topic, ps...)
+	}
+	responses, err := c.admClient.DeleteOffsets(ctx, group, set)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete offsets: %w", err)
+	}
+	return responses, nil
+}
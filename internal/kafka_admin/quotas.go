@@ -0,0 +1,45 @@
+package kafkaadmin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+)
+
+// DescribeClientQuotas lists every entity with a quota: users, client ids,
+// their combinations and defaults, and IPs.
+func (c *Client) DescribeClientQuotas(ctx context.Context) (kadm.DescribedClientQuotas, error) {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	// No components and no strict matching matches every entity.
+	quotas, err := c.admClient.DescribeClientQuotas(ctx, false, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe client quotas: %w", err)
+	}
+	return quotas, nil
+}
+
+// AlterClientQuotas sets or removes quotas on a single entity. Removing the
+// last quota removes the entity.
+func (c *Client) AlterClientQuotas(ctx context.Context, entity kadm.ClientQuotaEntity, ops []kadm.AlterClientQuotaOp) error {
+	if c.opts.ReadOnly {
+		return ErrReadOnly
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	altered, err := c.admClient.AlterClientQuotas(ctx, []kadm.AlterClientQuotaEntry{{Entity: entity, Ops: ops}})
+	if err != nil {
+		return fmt.Errorf("failed to alter client quotas: %w", err)
+	}
+	for _, a := range altered {
+		if a.Err != nil {
+			return fmt.Errorf("%s: %w", a.Entity, withErrMessage(a.Err, a.ErrMessage))
+		}
+	}
+	return nil
+}
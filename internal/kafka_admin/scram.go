@@ -0,0 +1,71 @@
+package kafkaadmin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+)
+
+// DescribeSCRAMUsers lists every user with a SCRAM credential, and the
+// mechanisms and iterations of each.
+func (c *Client) DescribeSCRAMUsers(ctx context.Context) (kadm.DescribedUserSCRAMs, error) {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	described, err := c.admClient.DescribeUserSCRAMs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe SCRAM users: %w", err)
+	}
+	for user, d := range described {
+		if d.Err != nil {
+			d.Err = withErrMessage(d.Err, d.ErrMessage)
+			described[user] = d
+		}
+	}
+	return described, nil
+}
+
+// UpsertSCRAMUser creates the user's credential for the mechanism, or
+// replaces its password if one exists. The password is salted locally and
+// only the salted form is sent.
+func (c *Client) UpsertSCRAMUser(ctx context.Context, upsert kadm.UpsertSCRAM) error {
+	if c.opts.ReadOnly {
+		return ErrReadOnly
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	altered, err := c.admClient.AlterUserSCRAMs(ctx, nil, []kadm.UpsertSCRAM{upsert})
+	if err != nil {
+		return fmt.Errorf("failed to set SCRAM credential: %w", err)
+	}
+	return alteredSCRAMError(altered)
+}
+
+// DeleteSCRAMCredential removes the user's credential for one mechanism.
+func (c *Client) DeleteSCRAMCredential(ctx context.Context, user string, mechanism kadm.ScramMechanism) error {
+	if c.opts.ReadOnly {
+		return ErrReadOnly
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	altered, err := c.admClient.AlterUserSCRAMs(ctx, []kadm.DeleteSCRAM{{User: user, Mechanism: mechanism}}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete SCRAM credential: %w", err)
+	}
+	return alteredSCRAMError(altered)
+}
+
+func alteredSCRAMError(altered kadm.AlteredUserSCRAMs) error {
+	for _, a := range altered.Sorted() {
+		if a.Err != nil {
+			return fmt.Errorf("%s: %w", a.User, withErrMessage(a.Err, a.ErrMessage))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,588 @@
+package serde
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hamba/avro/v2"
+	"github.com/twmb/franz-go/pkg/sr"
+)
+
+// avroDeserializer decodes Avro in Confluent's wire format, looking the
+// writer's schema up in a schema registry, and renders it as JSON.
+type avroDeserializer struct {
+	registry *SchemaRegistry
+
+	mu      sync.Mutex
+	schemas map[int]avro.Schema
+}
+
+// NewAvro returns a deserializer for Avro records whose schemas are kept in
+// registry.
+func NewAvro(registry *SchemaRegistry) Deserializer {
+	return &avroDeserializer{registry: registry, schemas: make(map[int]avro.Schema)}
+}
+
+func (*avroDeserializer) Name() string { return "avro" }
+
+func (d *avroDeserializer) Detect(_ string, data []byte) bool {
+	return d.registry.hasSchema(data, sr.TypeAvro)
+}
+
+func (d *avroDeserializer) Deserialize(_ string, data []byte) (string, error) {
+	id, payload, err := splitWireHeader(data)
+	if err != nil {
+		return "", err
+	}
+	schema, err := d.schema(id)
+	if err != nil {
+		return "", err
+	}
+
+	var v any
+	if err := avro.Unmarshal(schema, payload, &v); err != nil {
+		return "", fmt.Errorf("schema %d: %w", id, err)
+	}
+	var b bytes.Buffer
+	if err := writeAvroJSON(&b, schema, v); err != nil {
+		return "", fmt.Errorf("schema %d: %w", id, err)
+	}
+	return b.String(), nil
+}
+
+// Serialize encodes JSON with the latest schema registered for the topic's
+// key or value subject. It takes the same JSON Deserialize writes.
+func (d *avroDeserializer) Serialize(topic string, part Part, text string) ([]byte, error) {
+	ss, err := d.registry.latest(part.subject(topic))
+	if err != nil {
+		return nil, err
+	}
+	schema, err := d.schema(ss.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(strings.NewReader(text))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("not valid JSON: %w", err)
+	}
+	if dec.More() {
+		return nil, errors.New("not valid JSON: more than one value")
+	}
+	native, err := avroNative(schema, v, string(part))
+	if err != nil {
+		return nil, err
+	}
+	payload, err := avro.Marshal(schema, native)
+	if err != nil {
+		return nil, fmt.Errorf("schema %d: %w", ss.ID, err)
+	}
+
+	var header sr.ConfluentHeader
+	data, _ := header.AppendEncode(nil, ss.ID, nil)
+	return append(data, payload...), nil
+}
+
+// schema parses the Avro schema registered under id, along with the
+// schemas it references. Every ID gets its own cache of named types, as
+// different versions of a schema reuse the same names.
+func (d *avroDeserializer) schema(id int) (avro.Schema, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if schema, ok := d.schemas[id]; ok {
+		return schema, nil
+	}
+
+	s, err := d.registry.schemaByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if s.Type != sr.TypeAvro {
+		return nil, fmt.Errorf("schema %d is %s, not Avro", id, s.Type)
+	}
+	refs, err := d.registry.references(s)
+	if err != nil {
+		return nil, err
+	}
+
+	names := &avro.SchemaCache{}
+	for _, ref := range refs {
+		if _, err := avro.ParseWithCache(ref.schema.Schema, "", names); err != nil {
+			return nil, fmt.Errorf("schema %d: reference %s: %w", id, ref.name, err)
+		}
+	}
+	schema, err := avro.ParseWithCache(s.Schema, "", names)
+	if err != nil {
+		return nil, fmt.Errorf("schema %d: %w", id, err)
+	}
+	d.schemas[id] = schema
+	return schema, nil
+}
+
+// writeAvroJSON writes a decoded Avro value as JSON, walking the schema
+// alongside it so record fields keep their declared order. Union values are
+// written without the branch name Avro's own JSON encoding wraps them in.
+func writeAvroJSON(b *bytes.Buffer, schema avro.Schema, v any) error {
+	if v == nil {
+		b.WriteString("null")
+		return nil
+	}
+
+	switch s := schema.(type) {
+	case *avro.RefSchema:
+		return writeAvroJSON(b, s.Schema(), v)
+
+	case *avro.RecordSchema:
+		fields, ok := v.(map[string]any)
+		if !ok {
+			return writeJSONValue(b, schema, v)
+		}
+		b.WriteByte('{')
+		for i, f := range s.Fields() {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			writeJSONString(b, f.Name())
+			b.WriteByte(':')
+			if err := writeAvroJSON(b, f.Type(), fields[f.Name()]); err != nil {
+				return err
+			}
+		}
+		b.WriteByte('}')
+		return nil
+
+	case *avro.ArraySchema:
+		items, ok := v.([]any)
+		if !ok {
+			return writeJSONValue(b, schema, v)
+		}
+		b.WriteByte('[')
+		for i, item := range items {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			if err := writeAvroJSON(b, s.Items(), item); err != nil {
+				return err
+			}
+		}
+		b.WriteByte(']')
+		return nil
+
+	case *avro.MapSchema:
+		entries, ok := v.(map[string]any)
+		if !ok {
+			return writeJSONValue(b, schema, v)
+		}
+		keys := make([]string, 0, len(entries))
+		for k := range entries {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		b.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			writeJSONString(b, k)
+			b.WriteByte(':')
+			if err := writeAvroJSON(b, s.Values(), entries[k]); err != nil {
+				return err
+			}
+		}
+		b.WriteByte('}')
+		return nil
+
+	case *avro.UnionSchema:
+		// Unions with a single non-null branch decode to the bare value,
+		// others to a map from the branch's name to the value.
+		if branch, ok := v.(map[string]any); ok && len(branch) == 1 {
+			for name, value := range branch {
+				for _, t := range s.Types() {
+					if avroTypeName(t) == name {
+						return writeAvroJSON(b, t, value)
+					}
+				}
+			}
+		}
+		if s.Nullable() {
+			for _, t := range s.Types() {
+				if t.Type() != avro.Null {
+					return writeAvroJSON(b, t, v)
+				}
+			}
+		}
+		return writeJSONValue(b, schema, v)
+	}
+
+	return writeJSONValue(b, schema, v)
+}
+
+// avroTypeName is the name a union branch of type t is decoded under, and
+// encoded from.
+func avroTypeName(t avro.Schema) string {
+	if ref, ok := t.(*avro.RefSchema); ok {
+		return ref.Schema().FullName()
+	}
+	if named, ok := t.(avro.NamedSchema); ok {
+		return named.FullName()
+	}
+	if logical := avroLogicalType(t); logical != "" {
+		return string(t.Type()) + "." + string(logical)
+	}
+	return string(t.Type())
+}
+
+func avroLogicalType(t avro.Schema) avro.LogicalType {
+	if ls, ok := t.(avro.LogicalTypeSchema); ok && ls.Logical() != nil {
+		return ls.Logical().Type()
+	}
+	return ""
+}
+
+// writeJSONValue writes a primitive, enum or fixed value. Bytes and fixed
+// values become base64 strings, decimals keep their scale and durations
+// are written out as text.
+func writeJSONValue(b *bytes.Buffer, schema avro.Schema, v any) error {
+	switch value := v.(type) {
+	case *big.Rat:
+		scale := 0
+		if ls, ok := schema.(avro.LogicalTypeSchema); ok {
+			if decimal, ok := ls.Logical().(*avro.DecimalLogicalSchema); ok {
+				scale = decimal.Scale()
+			}
+		}
+		b.WriteString(value.FloatString(scale))
+		return nil
+	case time.Duration:
+		v = value.String()
+	default:
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+			fixed := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(fixed), rv)
+			v = fixed
+		}
+	}
+
+	return writeJSON(b, v)
+}
+
+func writeJSONString(b *bytes.Buffer, s string) {
+	writeJSON(b, s)
+}
+
+// writeJSON appends v without escaping HTML characters, which only get in
+// the way of reading the text.
+func writeJSON(b *bytes.Buffer, v any) error {
+	enc := json.NewEncoder(b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	b.Truncate(b.Len() - 1)
+	return nil
+}
+
+// avroNative converts a value decoded from JSON, with numbers as
+// json.Number, into the Go value the Avro encoder takes for schema. Unions
+// take either a bare value, matched against each branch in turn, or an
+// object naming the branch; bytes and fixed take base64; timestamps and
+// dates take RFC 3339 or a number in the schema's unit. path names the
+// value in errors.
+func avroNative(schema avro.Schema, v any, path string) (any, error) {
+	mismatch := func(want string) error {
+		return fmt.Errorf("%s: expected %s, got %s", path, want, jsonKind(v))
+	}
+
+	switch s := schema.(type) {
+	case *avro.RefSchema:
+		return avroNative(s.Schema(), v, path)
+
+	case *avro.RecordSchema:
+		fields, ok := v.(map[string]any)
+		if !ok {
+			return nil, mismatch("an object for " + s.FullName())
+		}
+		native := make(map[string]any, len(fields))
+		for _, f := range s.Fields() {
+			value, ok := fields[f.Name()]
+			if !ok {
+				if f.HasDefault() {
+					continue
+				}
+				return nil, fmt.Errorf("%s: missing field %s", path, f.Name())
+			}
+			converted, err := avroNative(f.Type(), value, path+"."+f.Name())
+			if err != nil {
+				return nil, err
+			}
+			native[f.Name()] = converted
+		}
+		for name := range fields {
+			if !hasField(s, name) {
+				return nil, fmt.Errorf("%s: %s has no field %s", path, s.FullName(), name)
+			}
+		}
+		return native, nil
+
+	case *avro.ArraySchema:
+		items, ok := v.([]any)
+		if !ok {
+			return nil, mismatch("an array")
+		}
+		native := make([]any, len(items))
+		for i, item := range items {
+			converted, err := avroNative(s.Items(), item, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			native[i] = converted
+		}
+		return native, nil
+
+	case *avro.MapSchema:
+		entries, ok := v.(map[string]any)
+		if !ok {
+			return nil, mismatch("an object")
+		}
+		native := make(map[string]any, len(entries))
+		for k, value := range entries {
+			converted, err := avroNative(s.Values(), value, path+"."+k)
+			if err != nil {
+				return nil, err
+			}
+			native[k] = converted
+		}
+		return native, nil
+
+	case *avro.UnionSchema:
+		if v == nil {
+			if slices.ContainsFunc(s.Types(), func(t avro.Schema) bool { return t.Type() == avro.Null }) {
+				return nil, nil
+			}
+			return nil, mismatch("a value")
+		}
+		if branch, ok := v.(map[string]any); ok && len(branch) == 1 {
+			for name, value := range branch {
+				for _, t := range s.Types() {
+					if avroTypeName(t) == name {
+						converted, err := avroNative(t, value, path)
+						return map[string]any{name: converted}, err
+					}
+				}
+			}
+		}
+		var names []string
+		for _, t := range s.Types() {
+			if t.Type() == avro.Null {
+				continue
+			}
+			if converted, err := avroNative(t, v, path); err == nil {
+				return map[string]any{avroTypeName(t): converted}, nil
+			}
+			names = append(names, avroTypeName(t))
+		}
+		return nil, mismatch("one of " + strings.Join(names, ", "))
+
+	case *avro.EnumSchema:
+		symbol, ok := v.(string)
+		if !ok || !slices.Contains(s.Symbols(), symbol) {
+			return nil, mismatch("one of " + strings.Join(s.Symbols(), ", "))
+		}
+		return symbol, nil
+
+	case *avro.FixedSchema:
+		if avroLogicalType(s) == avro.Decimal {
+			return avroDecimal(v, mismatch)
+		}
+		data, err := avroBytes(v, mismatch)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) != s.Size() {
+			return nil, fmt.Errorf("%s: expected %d bytes, got %d", path, s.Size(), len(data))
+		}
+		fixed := reflect.New(reflect.ArrayOf(s.Size(), reflect.TypeFor[byte]())).Elem()
+		reflect.Copy(fixed, reflect.ValueOf(data))
+		return fixed.Interface(), nil
+	}
+
+	switch logical := avroLogicalType(schema); logical {
+	case avro.Decimal:
+		return avroDecimal(v, mismatch)
+	case avro.Date, avro.TimestampMillis, avro.TimestampMicros, avro.LocalTimestampMillis, avro.LocalTimestampMicros:
+		return avroTime(logical, v, mismatch)
+	case avro.TimeMillis, avro.TimeMicros:
+		return avroTimeOfDay(logical, v, mismatch)
+	}
+
+	switch schema.Type() {
+	case avro.Null:
+		if v != nil {
+			return nil, mismatch("null")
+		}
+		return nil, nil
+	case avro.Boolean:
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+		return nil, mismatch("true or false")
+	case avro.Int:
+		n, ok := v.(json.Number)
+		if !ok {
+			return nil, mismatch("a 32-bit integer")
+		}
+		i, err := strconv.ParseInt(n.String(), 10, 32)
+		if err != nil {
+			return nil, mismatch("a 32-bit integer")
+		}
+		return int(i), nil
+	case avro.Long:
+		n, ok := v.(json.Number)
+		if !ok {
+			return nil, mismatch("an integer")
+		}
+		i, err := n.Int64()
+		if err != nil {
+			return nil, mismatch("an integer")
+		}
+		return i, nil
+	case avro.Float, avro.Double:
+		n, ok := v.(json.Number)
+		if !ok {
+			return nil, mismatch("a number")
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return nil, mismatch("a number")
+		}
+		if schema.Type() == avro.Float {
+			return float32(f), nil
+		}
+		return f, nil
+	case avro.String:
+		if str, ok := v.(string); ok {
+			return str, nil
+		}
+		return nil, mismatch("a string")
+	case avro.Bytes:
+		return avroBytes(v, mismatch)
+	}
+	return nil, fmt.Errorf("%s: unsupported schema type %s", path, schema.Type())
+}
+
+func hasField(s *avro.RecordSchema, name string) bool {
+	for _, f := range s.Fields() {
+		if f.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+func avroBytes(v any, mismatch func(string) error) ([]byte, error) {
+	str, ok := v.(string)
+	if !ok {
+		return nil, mismatch("base64 text")
+	}
+	data, err := base64.StdEncoding.DecodeString(str)
+	if err != nil {
+		return nil, mismatch("base64 text")
+	}
+	return data, nil
+}
+
+func avroDecimal(v any, mismatch func(string) error) (*big.Rat, error) {
+	var text string
+	switch value := v.(type) {
+	case json.Number:
+		text = value.String()
+	case string:
+		text = value
+	}
+	r, ok := new(big.Rat).SetString(text)
+	if !ok {
+		return nil, mismatch("a decimal number")
+	}
+	return r, nil
+}
+
+// avroTime reads an RFC 3339 time, a date for the date type, or a number
+// of the logical type's unit since the epoch.
+func avroTime(logical avro.LogicalType, v any, mismatch func(string) error) (time.Time, error) {
+	switch value := v.(type) {
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			return t, nil
+		}
+		if t, err := time.Parse(time.DateOnly, value); err == nil && logical == avro.Date {
+			return t, nil
+		}
+	case json.Number:
+		if n, err := value.Int64(); err == nil {
+			switch logical {
+			case avro.Date:
+				return time.Unix(n*24*60*60, 0).UTC(), nil
+			case avro.TimestampMillis, avro.LocalTimestampMillis:
+				return time.UnixMilli(n).UTC(), nil
+			default:
+				return time.UnixMicro(n).UTC(), nil
+			}
+		}
+	}
+	if logical == avro.Date {
+		return time.Time{}, mismatch("a date such as 2024-01-31")
+	}
+	return time.Time{}, mismatch("an RFC 3339 time")
+}
+
+// avroTimeOfDay reads a duration since midnight, such as 13h30m, or a
+// number of the logical type's unit.
+func avroTimeOfDay(logical avro.LogicalType, v any, mismatch func(string) error) (time.Duration, error) {
+	switch value := v.(type) {
+	case string:
+		if d, err := time.ParseDuration(value); err == nil {
+			return d, nil
+		}
+	case json.Number:
+		if n, err := value.Int64(); err == nil {
+			if logical == avro.TimeMillis {
+				return time.Duration(n) * time.Millisecond, nil
+			}
+			return time.Duration(n) * time.Microsecond, nil
+		}
+	}
+	return 0, mismatch("a time of day such as 13h30m")
+}
+
+// jsonKind names the JSON type of a decoded value for errors.
+func jsonKind(v any) string {
+	switch value := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(value)
+	case json.Number:
+		return value.String()
+	case string:
+		return strconv.Quote(value)
+	case []any:
+		return "an array"
+	case map[string]any:
+		return "an object"
+	}
+	return fmt.Sprintf("%T", v)
+}
@@ -0,0 +1,340 @@
+package serde
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hamba/avro/v2"
+	"github.com/twmb/franz-go/pkg/sr"
+)
+
+const (
+	addressSchema = `{"type": "record", "name": "Address", "namespace": "shop", "fields": [
+		{"name": "city", "type": "string"}
+	]}`
+
+	orderSchema = `{"type": "record", "name": "Order", "namespace": "shop", "fields": [
+		{"name": "id", "type": "string"},
+		{"name": "note", "type": ["null", "string"]},
+		{"name": "ship_to", "type": ["null", "shop.Address"]},
+		{"name": "placed", "type": ["null", {"type": "long", "logicalType": "timestamp-millis"}]},
+		{"name": "total", "type": {"type": "bytes", "logicalType": "decimal", "precision": 6, "scale": 2}},
+		{"name": "tags", "type": {"type": "map", "values": "int"}}
+	]}`
+
+	eventSchema = `{"type": "record", "name": "Event", "namespace": "shop", "fields": [
+		{"name": "kind", "type": {"type": "enum", "name": "Kind", "symbols": ["CREATED", "SHIPPED"]}},
+		{"name": "at", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+		{"name": "day", "type": {"type": "int", "logicalType": "date"}},
+		{"name": "cutoff", "type": {"type": "int", "logicalType": "time-millis"}},
+		{"name": "hash", "type": {"type": "fixed", "name": "Hash", "size": 4}},
+		{"name": "payload", "type": "bytes"},
+		{"name": "value", "type": ["null", "string", "long"]},
+		{"name": "ratio", "type": "double"},
+		{"name": "items", "type": {"type": "array", "items": "int"}},
+		{"name": "retries", "type": "int", "default": 3}
+	]}`
+)
+
+// avroResponses serves the order schema as ID 1, referencing the address
+// schema as version 1 of its subject, and the event schema as ID 3. Each
+// is also the latest version of its topic's value subject.
+var avroResponses = map[string]any{
+	"/schemas/ids/1": orderByID,
+	"/subjects/orders-value/versions/latest": map[string]any{
+		"subject": "orders-value", "version": 1, "id": 1, "schema": orderSchema, "references": orderByID["references"],
+	},
+	"/subjects/address-value/versions/1": map[string]any{
+		"subject": "address-value", "version": 1, "id": 2, "schema": addressSchema,
+	},
+	"/schemas/ids/3": map[string]any{"schema": eventSchema},
+	"/subjects/events-value/versions/latest": map[string]any{
+		"subject": "events-value", "version": 1, "id": 3, "schema": eventSchema,
+	},
+}
+
+var orderByID = map[string]any{
+	"schema": orderSchema,
+	"references": []map[string]any{
+		{"name": "shop.Address", "subject": "address-value", "version": 1},
+	},
+}
+
+// testRegistry serves fixed responses by path and counts requests.
+type testRegistry struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests map[string]int
+}
+
+func newTestRegistry(t *testing.T, responses map[string]any) *testRegistry {
+	t.Helper()
+
+	reg := &testRegistry{requests: make(map[string]int)}
+	reg.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reg.mu.Lock()
+		reg.requests[r.URL.Path]++
+		reg.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error_code": 40403, "message": "Schema not found"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(reg.Close)
+	return reg
+}
+
+func (r *testRegistry) count(path string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.requests[path]
+}
+
+// encodeOrder writes order in Confluent's wire format under schema ID 1.
+func encodeOrder(t *testing.T, order map[string]any) []byte {
+	t.Helper()
+
+	cache := &avro.SchemaCache{}
+	if _, err := avro.ParseWithCache(addressSchema, "", cache); err != nil {
+		t.Fatal(err)
+	}
+	schema, err := avro.ParseWithCache(orderSchema, "", cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, err := avro.Marshal(schema, order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var header sr.ConfluentHeader
+	data, _ := header.AppendEncode(nil, 1, nil)
+	return append(data, payload...)
+}
+
+func newTestAvro(t *testing.T, reg *testRegistry) (*SchemaRegistry, Deserializer) {
+	t.Helper()
+
+	schemas, err := NewSchemaRegistry(SchemaRegistryOptions{URL: reg.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schemas, NewAvro(schemas)
+}
+
+func TestAvroDeserialize(t *testing.T) {
+	reg := newTestRegistry(t, avroResponses)
+	_, d := newTestAvro(t, reg)
+
+	tests := []struct {
+		name  string
+		order map[string]any
+		want  string
+	}{
+		{
+			name: "set",
+			order: map[string]any{
+				"id":      "o-1",
+				"note":    "leave at the door",
+				"ship_to": map[string]any{"shop.Address": map[string]any{"city": "Oslo"}},
+				"placed":  map[string]any{"long.timestamp-millis": time.UnixMilli(1700000000000).UTC()},
+				"total":   big.NewRat(1999, 100),
+				"tags":    map[string]any{"gift": 1, "express": 2},
+			},
+			want: `{"id":"o-1","note":"leave at the door","ship_to":{"city":"Oslo"},"placed":"2023-11-14T22:13:20Z","total":19.99,"tags":{"express":2,"gift":1}}`,
+		},
+		{
+			name: "null",
+			order: map[string]any{
+				"id":      "o-2",
+				"note":    nil,
+				"ship_to": nil,
+				"placed":  nil,
+				"total":   big.NewRat(0, 1),
+				"tags":    map[string]any{},
+			},
+			want: `{"id":"o-2","note":null,"ship_to":null,"placed":null,"total":0.00,"tags":{}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := d.Deserialize("orders", encodeOrder(t, tt.order))
+			if err != nil {
+				t.Fatalf("Deserialize: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Deserialize:\ngot  %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+
+	if n := reg.count("/schemas/ids/1"); n != 1 {
+		t.Errorf("schema 1 fetched %d times, want once", n)
+	}
+	if n := reg.count("/subjects/address-value/versions/1"); n != 1 {
+		t.Errorf("referenced schema fetched %d times, want once", n)
+	}
+}
+
+func TestAvroRetriesFailedLookup(t *testing.T) {
+	reg := newTestRegistry(t, avroResponses)
+	schemas, d := newTestAvro(t, reg)
+
+	unknown := []byte{0, 0, 0, 0, 9, 2}
+	for range 2 {
+		if _, err := d.Deserialize("orders", unknown); err == nil {
+			t.Fatal("Deserialize: expected an error for an unknown schema")
+		}
+	}
+	if n := reg.count("/schemas/ids/9"); n != 1 {
+		t.Fatalf("unknown schema fetched %d times, want once until the retry delay passes", n)
+	}
+
+	schemas.mu.Lock()
+	l := schemas.byID[9]
+	l.at = l.at.Add(-retryLookupAfter)
+	schemas.byID[9] = l
+	schemas.mu.Unlock()
+
+	d.Deserialize("orders", unknown)
+	if n := reg.count("/schemas/ids/9"); n != 2 {
+		t.Errorf("unknown schema fetched %d times after the retry delay, want twice", n)
+	}
+}
+
+func TestAvroDetect(t *testing.T) {
+	reg := newTestRegistry(t, avroResponses)
+	_, d := newTestAvro(t, reg)
+	detector := d.(Detector)
+
+	order := encodeOrder(t, map[string]any{
+		"id": "o-1", "note": nil, "ship_to": nil, "placed": nil, "total": big.NewRat(1, 1), "tags": map[string]any{},
+	})
+	if !detector.Detect("orders", order) {
+		t.Error("Detect: rejected a registered Avro schema")
+	}
+	if detector.Detect("orders", []byte("plain text")) {
+		t.Error("Detect: accepted a payload without a wire format header")
+	}
+
+	// A big-endian int64 key looks just like a wire format header, for
+	// schema ID 0 here, which is looked up once and then remembered.
+	key := []byte{0, 0, 0, 0, 0, 0, 0, 42}
+	for range 2 {
+		if detector.Detect("orders", key) {
+			t.Error("Detect: accepted an integer key")
+		}
+	}
+	if n := reg.count("/schemas/ids/0"); n != 1 {
+		t.Errorf("schema 0 fetched %d times, want once", n)
+	}
+}
+
+func TestAvroSerializeRoundTrip(t *testing.T) {
+	reg := newTestRegistry(t, avroResponses)
+	_, d := newTestAvro(t, reg)
+	serializer := d.(Serializer)
+
+	tests := []struct {
+		name  string
+		topic string
+		text  string
+		want  string // empty when the text reads back unchanged
+	}{
+		{
+			name:  "referenced record and nullable unions",
+			topic: "orders",
+			text:  `{"id":"o-1","note":"leave at the door","ship_to":{"city":"Oslo"},"placed":"2023-11-14T22:13:20Z","total":19.99,"tags":{"express":2,"gift":1}}`,
+		},
+		{
+			name:  "nulls",
+			topic: "orders",
+			text:  `{"id":"o-2","note":null,"ship_to":null,"placed":null,"total":0.00,"tags":{}}`,
+		},
+		{
+			name:  "decimal as a string",
+			topic: "orders",
+			text:  `{"id":"o-3","note":null,"ship_to":null,"placed":null,"total":"1.5","tags":{}}`,
+			want:  `{"id":"o-3","note":null,"ship_to":null,"placed":null,"total":1.50,"tags":{}}`,
+		},
+		{
+			name:  "logical types, fixed and default",
+			topic: "events",
+			text:  `{"kind":"SHIPPED","at":"2024-01-31T12:00:00Z","day":"2024-01-31","cutoff":"13h30m","hash":"AQIDBA==","payload":"aGk=","value":5,"ratio":0.5,"items":[1,2]}`,
+			want:  `{"kind":"SHIPPED","at":"2024-01-31T12:00:00Z","day":"2024-01-31T00:00:00Z","cutoff":"13h30m0s","hash":"AQIDBA==","payload":"aGk=","value":5,"ratio":0.5,"items":[1,2],"retries":3}`,
+		},
+		{
+			name:  "numbers in the logical types' units and a named union branch",
+			topic: "events",
+			text:  `{"kind":"CREATED","at":1700000000000,"day":19753,"cutoff":1000,"hash":"AQIDBA==","payload":"","value":{"string":"5"},"ratio":1,"items":[],"retries":7}`,
+			want:  `{"kind":"CREATED","at":"2023-11-14T22:13:20Z","day":"2024-01-31T00:00:00Z","cutoff":"1s","hash":"AQIDBA==","payload":"","value":"5","ratio":1,"items":[],"retries":7}`,
+		},
+		{
+			name:  "null in a union of three",
+			topic: "events",
+			text:  `{"kind":"CREATED","at":"1970-01-01T00:00:00Z","day":"1970-01-01T00:00:00Z","cutoff":"0s","hash":"AAAAAA==","payload":"","value":null,"ratio":0,"items":[],"retries":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := serializer.Serialize(tt.topic, ValuePart, tt.text)
+			if err != nil {
+				t.Fatalf("Serialize: %v", err)
+			}
+			got, err := d.Deserialize(tt.topic, data)
+			if err != nil {
+				t.Fatalf("Deserialize: %v", err)
+			}
+			want := tt.want
+			if want == "" {
+				want = tt.text
+			}
+			if got != want {
+				t.Errorf("round trip:\ngot  %s\nwant %s", got, want)
+			}
+		})
+	}
+}
+
+func TestAvroSerializeErrors(t *testing.T) {
+	reg := newTestRegistry(t, avroResponses)
+	_, d := newTestAvro(t, reg)
+	serializer := d.(Serializer)
+
+	const event = `"kind":"CREATED","at":0,"day":0,"cutoff":0,"hash":"AQIDBA==","payload":"","value":null,"ratio":0,"items":[]`
+	tests := []struct {
+		name    string
+		topic   string
+		text    string
+		wantErr string
+	}{
+		{name: "not JSON", topic: "events", text: `{"kind":`, wantErr: "not valid JSON"},
+		{name: "no subject", topic: "missing", text: `{}`, wantErr: "subject missing-value"},
+		{name: "unknown field", topic: "events", text: `{` + event + `,"colour":"red"}`, wantErr: "shop.Event has no field colour"},
+		{name: "missing field", topic: "events", text: `{"kind":"CREATED"}`, wantErr: "value: missing field at"},
+		{name: "int overflow", topic: "events", text: `{` + event + `,"retries":3000000000}`, wantErr: "value.retries: expected a 32-bit integer, got 3000000000"},
+		{name: "unknown symbol", topic: "events", text: `{` + strings.Replace(event, `"CREATED"`, `"LOST"`, 1) + `}`, wantErr: "value.kind: expected one of CREATED, SHIPPED"},
+		{name: "fixed size", topic: "events", text: `{` + strings.Replace(event, `"AQIDBA=="`, `"AQI="`, 1) + `}`, wantErr: "value.hash: expected 4 bytes, got 2"},
+		{name: "no union branch", topic: "events", text: `{` + strings.Replace(event, `"value":null`, `"value":true`, 1) + `}`, wantErr: "value.value: expected one of string, long, got true"},
+		{name: "date", topic: "events", text: `{` + strings.Replace(event, `"day":0`, `"day":"yesterday"`, 1) + `}`, wantErr: "a date such as 2024-01-31"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := serializer.Serialize(tt.topic, ValuePart, tt.text)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Serialize: got error %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package serde
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// builtins are registered in this order, so auto detection tries JSON
+// before plain text.
+var builtins = []Deserializer{
+	utf8String{},
+	jsonText{},
+	hexDump{},
+	base64Text{},
+	int32BE{},
+	int64BE{},
+	float64BE{},
+	uuidText{},
+	rawBytes{},
+}
+
+type utf8String struct{}
+
+func (utf8String) Name() string { return "string" }
+
+// Detect accepts text without control characters, which would otherwise
+// be better off in a hex dump.
+func (utf8String) Detect(_ string, data []byte) bool {
+	return utf8.Valid(data) && !bytes.ContainsFunc(data, func(r rune) bool {
+		return !unicode.IsPrint(r) && !unicode.IsSpace(r)
+	})
+}
+
+func (utf8String) Deserialize(_ string, data []byte) (string, error) {
+	if !utf8.Valid(data) {
+		return "", errors.New("not valid UTF-8")
+	}
+	return escapeControl(string(data)), nil
+}
+
+func (utf8String) Serialize(_ string, _ Part, text string) ([]byte, error) {
+	return []byte(text), nil
+}
+
+type jsonText struct{}
+
+func (jsonText) Name() string { return "json" }
+
+// Detect only accepts objects and arrays; a bare number or string is
+// better shown as text.
+func (jsonText) Detect(_ string, data []byte) bool {
+	trimmed := bytes.TrimSpace(data)
+	return len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid(trimmed)
+}
+
+func (jsonText) Deserialize(_ string, data []byte) (string, error) {
+	trimmed := bytes.TrimSpace(data)
+	if !json.Valid(trimmed) {
+		return "", errors.New("not valid JSON")
+	}
+	return string(trimmed), nil
+}
+
+// Serialize checks the text is JSON and sends it as typed, whitespace and
+// all, apart from the ends.
+func (jsonText) Serialize(_ string, _ Part, text string) ([]byte, error) {
+	trimmed := strings.TrimSpace(text)
+	var v any
+	if err := json.Unmarshal([]byte(trimmed), &v); err != nil {
+		return nil, err
+	}
+	return []byte(trimmed), nil
+}
+
+type hexDump struct{}
+
+func (hexDump) Name() string { return "hex" }
+
+func (hexDump) Deserialize(_ string, data []byte) (string, error) {
+	return strings.TrimRight(hex.Dump(data), "\n"), nil
+}
+
+// Serialize takes plain hex digits, optionally prefixed with 0x and
+// grouped with spaces.
+func (hexDump) Serialize(_ string, _ Part, text string) ([]byte, error) {
+	digits := strings.Join(strings.Fields(strings.TrimPrefix(strings.TrimSpace(text), "0x")), "")
+	data, err := hex.DecodeString(digits)
+	if err != nil {
+		return nil, fmt.Errorf("not hex: %w", err)
+	}
+	return data, nil
+}
+
+type base64Text struct{}
+
+func (base64Text) Name() string { return "base64" }
+
+func (base64Text) Deserialize(_ string, data []byte) (string, error) {
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+func (base64Text) Serialize(_ string, _ Part, text string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(text))
+	if err != nil {
+		return nil, fmt.Errorf("not base64: %w", err)
+	}
+	return data, nil
+}
+
+func checkLen(data []byte, n int) error {
+	if len(data) != n {
+		return fmt.Errorf("need %d bytes, got %d", n, len(data))
+	}
+	return nil
+}
+
+type int32BE struct{}
+
+func (int32BE) Name() string { return "int32" }
+
+func (int32BE) Deserialize(_ string, data []byte) (string, error) {
+	if err := checkLen(data, 4); err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(int64(int32(binary.BigEndian.Uint32(data))), 10), nil
+}
+
+func (int32BE) Serialize(_ string, _ Part, text string) ([]byte, error) {
+	n, err := strconv.ParseInt(strings.TrimSpace(text), 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a 32-bit integer", text)
+	}
+	return binary.BigEndian.AppendUint32(nil, uint32(n)), nil
+}
+
+type int64BE struct{}
+
+func (int64BE) Name() string { return "int64" }
+
+func (int64BE) Deserialize(_ string, data []byte) (string, error) {
+	if err := checkLen(data, 8); err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(int64(binary.BigEndian.Uint64(data)), 10), nil
+}
+
+func (int64BE) Serialize(_ string, _ Part, text string) ([]byte, error) {
+	n, err := strconv.ParseInt(strings.TrimSpace(text), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a 64-bit integer", text)
+	}
+	return binary.BigEndian.AppendUint64(nil, uint64(n)), nil
+}
+
+type float64BE struct{}
+
+func (float64BE) Name() string { return "float64" }
+
+func (float64BE) Deserialize(_ string, data []byte) (string, error) {
+	if err := checkLen(data, 8); err != nil {
+		return "", err
+	}
+	return strconv.FormatFloat(math.Float64frombits(binary.BigEndian.Uint64(data)), 'g', -1, 64), nil
+}
+
+// uuidText reads the 16 raw bytes of a UUID, as written by UUID
+// serializers that don't use the string form.
+type uuidText struct{}
+
+func (uuidText) Name() string { return "uuid" }
+
+func (uuidText) Deserialize(_ string, data []byte) (string, error) {
+	if err := checkLen(data, 16); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x-%x-%x-%x-%x", data[0:4], data[4:6], data[6:8], data[8:10], data[10:16]), nil
+}
+
+// rawBytes shows the bytes as they are, escaping anything that isn't
+// printable.
+type rawBytes struct{}
+
+func (rawBytes) Name() string { return "raw" }
+
+func (rawBytes) Deserialize(_ string, data []byte) (string, error) {
+	quoted := strconv.Quote(string(data))
+	return quoted[1 : len(quoted)-1], nil
+}
+
+// tombstone produces a null key or value. It only serializes, as a null
+// is never handed to a deserializer.
+type tombstone struct{}
+
+func (tombstone) Name() string { return Null }
+
+func (tombstone) Serialize(_ string, part Part, text string) ([]byte, error) {
+	if text != "" {
+		return nil, fmt.Errorf("a null %s has no text; clear the field", part)
+	}
+	return nil, nil
+}
@@ -0,0 +1,55 @@
+package serde
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestBuiltinSerializers(t *testing.T) {
+	tests := []struct {
+		serializer string
+		text       string
+		want       []byte
+		wantErr    string
+	}{
+		{serializer: "string", text: " as is ", want: []byte(" as is ")},
+		{serializer: "json", text: ` {"a": [1, 2]} `, want: []byte(`{"a": [1, 2]}`)},
+		{serializer: "json", text: `{"a":`, wantErr: "unexpected end of JSON input"},
+		{serializer: "hex", text: "0xde ad\nbe ef", want: []byte{0xde, 0xad, 0xbe, 0xef}},
+		{serializer: "hex", text: "xyz", wantErr: "not hex"},
+		{serializer: "base64", text: " aGk= ", want: []byte("hi")},
+		{serializer: "base64", text: "a", wantErr: "not base64"},
+		{serializer: "int32", text: "-2", want: []byte{0xff, 0xff, 0xff, 0xfe}},
+		{serializer: "int32", text: "2147483648", wantErr: `"2147483648" is not a 32-bit integer`},
+		{serializer: "int64", text: "42", want: []byte{0, 0, 0, 0, 0, 0, 0, 42}},
+		{serializer: "int64", text: "4.2", wantErr: `"4.2" is not a 64-bit integer`},
+		{serializer: Null, text: "", want: nil},
+		{serializer: Null, text: "gone", wantErr: "a null value has no text"},
+		{serializer: "uuid", wantErr: `unknown serializer "uuid"`},
+	}
+	r := NewRegistry()
+	for _, tt := range tests {
+		t.Run(tt.serializer+" "+tt.text, func(t *testing.T) {
+			got, err := func() ([]byte, error) {
+				s, err := r.Serializer(tt.serializer)
+				if err != nil {
+					return nil, err
+				}
+				return s.Serialize("topic", ValuePart, tt.text)
+			}()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, tt.want) || (got == nil) != (tt.want == nil) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
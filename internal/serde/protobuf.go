@@ -0,0 +1,305 @@
+package serde
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/twmb/franz-go/pkg/sr"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// maxMessageIndexes bounds the message indexes read from a wire format
+// header, so a payload that only looks like one can't ask for a huge
+// allocation.
+const maxMessageIndexes = 32
+
+var protoJSON = protojson.MarshalOptions{UseProtoNames: true}
+
+// ProtobufOptions describe local message types, used for topics whose
+// records aren't in schema registry wire format or when there is no
+// registry.
+type ProtobufOptions struct {
+	// ImportPaths are searched for Files and their imports.
+	ImportPaths []string
+	// Files are .proto files, relative to one of ImportPaths.
+	Files []string
+	// DescriptorSets are serialized FileDescriptorSets, as written by
+	// protoc --descriptor_set_out or buf build.
+	DescriptorSets []string
+	// Topics maps a topic to the fully qualified message type it holds.
+	Topics map[string]string
+}
+
+// protobufDeserializer decodes protobuf and renders it as JSON. Records in
+// Confluent's wire format use the writer's schema from the registry, and
+// the message indexes that follow the schema ID to pick the message type.
+// Others use the local message type configured for their topic.
+type protobufDeserializer struct {
+	registry *SchemaRegistry
+	topics   map[string]protoreflect.MessageDescriptor
+
+	mu    sync.Mutex
+	files map[int]protoreflect.FileDescriptor
+}
+
+// NewProtobuf returns a protobuf deserializer for the schemas in registry,
+// which may be nil, and the local types in opts. Local files are loaded
+// here, so a missing file or message type is reported up front.
+func NewProtobuf(registry *SchemaRegistry, opts ProtobufOptions) (Deserializer, error) {
+	d := &protobufDeserializer{
+		registry: registry,
+		topics:   make(map[string]protoreflect.MessageDescriptor),
+		files:    make(map[int]protoreflect.FileDescriptor),
+	}
+	if len(opts.Topics) == 0 {
+		return d, nil
+	}
+
+	resolvers, err := loadProtoFiles(opts)
+	if err != nil {
+		return nil, err
+	}
+	for topic, name := range opts.Topics {
+		d.topics[topic], err = findMessage(resolvers, protoreflect.FullName(name))
+		if err != nil {
+			return nil, fmt.Errorf("protobuf type for topic %s: %w", topic, err)
+		}
+	}
+	return d, nil
+}
+
+type descriptorResolver interface {
+	FindDescriptorByName(protoreflect.FullName) (protoreflect.Descriptor, error)
+}
+
+func loadProtoFiles(opts ProtobufOptions) ([]descriptorResolver, error) {
+	var resolvers []descriptorResolver
+
+	if len(opts.Files) > 0 {
+		compiler := protocompile.Compiler{
+			Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{ImportPaths: opts.ImportPaths}),
+		}
+		files, err := compiler.Compile(context.Background(), opts.Files...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to compile .proto files: %w", err)
+		}
+		resolvers = append(resolvers, files.AsResolver())
+	}
+
+	for _, path := range opts.DescriptorSets {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read descriptor set: %w", err)
+		}
+		var set descriptorpb.FileDescriptorSet
+		if err := proto.Unmarshal(data, &set); err != nil {
+			return nil, fmt.Errorf("%s is not a FileDescriptorSet: %w", path, err)
+		}
+		files, err := protodesc.NewFiles(&set)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		resolvers = append(resolvers, files)
+	}
+	return resolvers, nil
+}
+
+func findMessage(resolvers []descriptorResolver, name protoreflect.FullName) (protoreflect.MessageDescriptor, error) {
+	for _, r := range resolvers {
+		if desc, err := r.FindDescriptorByName(name); err == nil {
+			if md, ok := desc.(protoreflect.MessageDescriptor); ok {
+				return md, nil
+			}
+			return nil, fmt.Errorf("%s is not a message", name)
+		}
+	}
+	return nil, fmt.Errorf("message %s not found", name)
+}
+
+func (*protobufDeserializer) Name() string { return "protobuf" }
+
+// Detect accepts wire format records on a topic with a local type, or whose
+// schema ID the registry has as a protobuf schema. Bare payloads on a
+// topic with a local type are only accepted when they decode without
+// unknown fields, as almost any bytes parse as some protobuf message.
+func (d *protobufDeserializer) Detect(topic string, data []byte) bool {
+	if hasWireHeader(data) {
+		return d.topics[topic] != nil || (d.registry != nil && d.registry.hasSchema(data, sr.TypeProtobuf))
+	}
+	md := d.topics[topic]
+	if md == nil {
+		return false
+	}
+	msg := dynamicpb.NewMessage(md)
+	return proto.Unmarshal(data, msg) == nil && len(msg.GetUnknown()) == 0
+}
+
+// Deserialize relies on a protobuf payload never starting with a zero
+// byte, as field number 0 is invalid, to tell wire format records apart.
+func (d *protobufDeserializer) Deserialize(topic string, data []byte) (string, error) {
+	md, payload, err := d.message(topic, data)
+	if err != nil {
+		return "", err
+	}
+
+	msg := dynamicpb.NewMessage(md)
+	if err := proto.Unmarshal(payload, msg); err != nil {
+		return "", fmt.Errorf("%s: %w", md.FullName(), err)
+	}
+	text, err := protoJSON.Marshal(msg)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", md.FullName(), err)
+	}
+	// protojson varies its whitespace on purpose; compact it so the same
+	// record always reads the same.
+	var b bytes.Buffer
+	if err := json.Compact(&b, text); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Serialize encodes protobuf JSON as the local type configured for the
+// topic, without a header. Other topics use the first message of the
+// latest schema registered for their key or value subject, in wire format.
+func (d *protobufDeserializer) Serialize(topic string, part Part, text string) ([]byte, error) {
+	if md := d.topics[topic]; md != nil {
+		return marshalProtoJSON(md, text)
+	}
+	if d.registry == nil {
+		return nil, fmt.Errorf("no protobuf type configured for topic %s", topic)
+	}
+
+	ss, err := d.registry.latest(part.subject(topic))
+	if err != nil {
+		return nil, err
+	}
+	fd, err := d.file(ss.ID)
+	if err != nil {
+		return nil, err
+	}
+	if fd.Messages().Len() == 0 {
+		return nil, fmt.Errorf("schema %d has no messages", ss.ID)
+	}
+	payload, err := marshalProtoJSON(fd.Messages().Get(0), text)
+	if err != nil {
+		return nil, err
+	}
+
+	var header sr.ConfluentHeader
+	data, _ := header.AppendEncode(nil, ss.ID, []int{0})
+	return append(data, payload...), nil
+}
+
+func marshalProtoJSON(md protoreflect.MessageDescriptor, text string) ([]byte, error) {
+	msg := dynamicpb.NewMessage(md)
+	if err := protojson.Unmarshal([]byte(text), msg); err != nil {
+		return nil, fmt.Errorf("%s: %w", md.FullName(), err)
+	}
+	return proto.Marshal(msg)
+}
+
+// message returns the message type data was written with and its payload.
+func (d *protobufDeserializer) message(topic string, data []byte) (protoreflect.MessageDescriptor, []byte, error) {
+	if !hasWireHeader(data) {
+		if md := d.topics[topic]; md != nil {
+			return md, data, nil
+		}
+		return nil, nil, fmt.Errorf("no protobuf type configured for topic %s", topic)
+	}
+
+	id, rest, err := splitWireHeader(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	var header sr.ConfluentHeader
+	indexes, payload, err := header.DecodeIndex(rest, maxMessageIndexes)
+	if err != nil {
+		return nil, nil, errors.New("no message indexes after the schema ID")
+	}
+
+	if d.registry == nil {
+		if md := d.topics[topic]; md != nil {
+			return md, payload, nil
+		}
+		return nil, nil, fmt.Errorf("schema %d needs a schema registry, or a protobuf type configured for topic %s", id, topic)
+	}
+	fd, err := d.file(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	md, err := messageAt(fd, indexes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("schema %d: %w", id, err)
+	}
+	return md, payload, nil
+}
+
+// file compiles the .proto source registered under id, resolving its
+// imports from the schemas it references and the well-known types.
+func (d *protobufDeserializer) file(id int) (protoreflect.FileDescriptor, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if fd, ok := d.files[id]; ok {
+		return fd, nil
+	}
+
+	s, err := d.registry.schemaByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if s.Type != sr.TypeProtobuf {
+		return nil, fmt.Errorf("schema %d is %s, not protobuf", id, s.Type)
+	}
+	refs, err := d.registry.references(s)
+	if err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("schema-%d.proto", id)
+	sources := map[string]string{name: s.Schema}
+	for _, ref := range refs {
+		sources[ref.name] = ref.schema.Schema
+	}
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{
+			Accessor: protocompile.SourceAccessorFromMap(sources),
+		}),
+	}
+	files, err := compiler.Compile(context.Background(), name)
+	if err != nil {
+		return nil, fmt.Errorf("schema %d: %w", id, err)
+	}
+	d.files[id] = files[0]
+	return files[0], nil
+}
+
+// messageAt follows indexes from the file's top-level messages down
+// through nested ones.
+func messageAt(fd protoreflect.FileDescriptor, indexes []int) (protoreflect.MessageDescriptor, error) {
+	var md protoreflect.MessageDescriptor
+	messages := fd.Messages()
+	for _, i := range indexes {
+		if i < 0 || i >= messages.Len() {
+			return nil, fmt.Errorf("no message at index %v", indexes)
+		}
+		md = messages.Get(i)
+		messages = md.Messages()
+	}
+	if md == nil {
+		return nil, errors.New("no message indexes")
+	}
+	return md, nil
+}
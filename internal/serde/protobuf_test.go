@@ -0,0 +1,276 @@
+package serde
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/twmb/franz-go/pkg/sr"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const (
+	amountProto = `syntax = "proto3";
+package pay;
+message Amount {
+  string currency = 1;
+  int64 cents = 2;
+}`
+
+	paymentProto = `syntax = "proto3";
+package pay;
+import "pay/amount.proto";
+message Envelope {
+  string id = 1;
+}
+message Payment {
+  message Detail {
+    string note = 1;
+  }
+  Amount amount = 1;
+  Detail detail = 2;
+}`
+)
+
+// protobufResponses serve the payment schema as ID 10 and as the latest
+// version of payments-value, importing the amount schema by reference.
+var protobufResponses = map[string]any{
+	"/schemas/ids/10": map[string]any{
+		"schema": paymentProto, "schemaType": "PROTOBUF", "references": paymentReferences,
+	},
+	"/subjects/payments-value/versions/latest": map[string]any{
+		"subject": "payments-value", "version": 1, "id": 10,
+		"schema": paymentProto, "schemaType": "PROTOBUF", "references": paymentReferences,
+	},
+	"/subjects/amount-value/versions/1": map[string]any{
+		"subject": "amount-value", "version": 1, "id": 11, "schema": amountProto, "schemaType": "PROTOBUF",
+	},
+	"/schemas/ids/1": orderByID,
+}
+
+var paymentReferences = []map[string]any{
+	{"name": "pay/amount.proto", "subject": "amount-value", "version": 1},
+}
+
+// encodeProto marshals the protobuf JSON text as the named message of the
+// payment schema, in wire format with indexes.
+func encodeProto(t *testing.T, name protoreflect.FullName, indexes []int, text string) []byte {
+	t.Helper()
+
+	compiler := protocompile.Compiler{
+		Resolver: &protocompile.SourceResolver{
+			Accessor: protocompile.SourceAccessorFromMap(map[string]string{
+				"pay/payment.proto": paymentProto,
+				"pay/amount.proto":  amountProto,
+			}),
+		},
+	}
+	files, err := compiler.Compile(context.Background(), "pay/payment.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	desc, err := files.AsResolver().FindDescriptorByName(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := dynamicpb.NewMessage(desc.(protoreflect.MessageDescriptor))
+	if err := protojson.Unmarshal([]byte(text), msg); err != nil {
+		t.Fatal(err)
+	}
+	payload, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var header sr.ConfluentHeader
+	data, _ := header.AppendEncode(nil, 10, indexes)
+	return append(data, payload...)
+}
+
+func newTestProtobuf(t *testing.T, reg *testRegistry, opts ProtobufOptions) Deserializer {
+	t.Helper()
+
+	var schemas *SchemaRegistry
+	if reg != nil {
+		var err error
+		if schemas, err = NewSchemaRegistry(SchemaRegistryOptions{URL: reg.URL}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	d, err := NewProtobuf(schemas, opts)
+	if err != nil {
+		t.Fatalf("NewProtobuf: %v", err)
+	}
+	return d
+}
+
+func TestProtobufRegistry(t *testing.T) {
+	reg := newTestRegistry(t, protobufResponses)
+	d := newTestProtobuf(t, reg, ProtobufOptions{})
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    string
+		wantErr string
+	}{
+		{
+			name: "first message",
+			data: encodeProto(t, "pay.Envelope", []int{0}, `{"id": "e-1"}`),
+			want: `{"id":"e-1"}`,
+		},
+		{
+			name: "referenced type",
+			data: encodeProto(t, "pay.Payment", []int{1}, `{"amount": {"currency": "EUR", "cents": 250}, "detail": {"note": "tip"}}`),
+			want: `{"amount":{"currency":"EUR","cents":"250"},"detail":{"note":"tip"}}`,
+		},
+		{
+			name: "nested message",
+			data: encodeProto(t, "pay.Payment.Detail", []int{1, 0}, `{"note": "tip"}`),
+			want: `{"note":"tip"}`,
+		},
+		{
+			name:    "index out of range",
+			data:    encodeProto(t, "pay.Envelope", []int{2}, `{}`),
+			wantErr: "schema 10: no message at index [2]",
+		},
+		{
+			name:    "avro schema",
+			data:    []byte{0, 0, 0, 0, 1, 0},
+			wantErr: "schema 1 is AVRO, not protobuf",
+		},
+		{
+			name:    "unknown schema",
+			data:    []byte{0, 0, 0, 0, 99, 0},
+			wantErr: "schema 99",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := d.Deserialize("payments", tt.data)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Deserialize: got error %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Deserialize: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Deserialize:\ngot  %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+
+	if n := reg.count("/subjects/amount-value/versions/1"); n != 1 {
+		t.Errorf("referenced schema fetched %d times, want once", n)
+	}
+
+	detector := d.(Detector)
+	if !detector.Detect("payments", encodeProto(t, "pay.Envelope", []int{0}, `{"id": "e-1"}`)) {
+		t.Error("Detect: rejected a registered protobuf schema")
+	}
+	if detector.Detect("payments", []byte{0, 0, 0, 0, 1, 0}) {
+		t.Error("Detect: accepted an Avro schema")
+	}
+}
+
+func TestProtobufSerializeRegistry(t *testing.T) {
+	reg := newTestRegistry(t, protobufResponses)
+	d := newTestProtobuf(t, reg, ProtobufOptions{})
+
+	data, err := d.(Serializer).Serialize("payments", ValuePart, `{"id": "e-2"}`)
+	if err != nil {
+		t.Fatalf("Serialize: %v", err)
+	}
+	if want := encodeProto(t, "pay.Envelope", []int{0}, `{"id": "e-2"}`); string(data) != string(want) {
+		t.Errorf("Serialize: got %x, want %x", data, want)
+	}
+	if _, err := d.(Serializer).Serialize("payments", ValuePart, `{"colour": "red"}`); err == nil {
+		t.Error("Serialize: accepted an unknown field")
+	}
+}
+
+// shop.binpb is the FileDescriptorSet of testdata/proto, as written by
+// buf build or protoc --include_imports --descriptor_set_out.
+func TestProtobufLocalTypes(t *testing.T) {
+	topics := map[string]string{"orders": "shop.Order"}
+	sources := map[string]ProtobufOptions{
+		"files":          {ImportPaths: []string{"testdata/proto"}, Files: []string{"shop/order.proto"}, Topics: topics},
+		"descriptor set": {DescriptorSets: []string{"testdata/shop.binpb"}, Topics: topics},
+	}
+	for name, opts := range sources {
+		t.Run(name, func(t *testing.T) {
+			d := newTestProtobuf(t, nil, opts)
+			serializer, detector := d.(Serializer), d.(Detector)
+
+			const text = `{"id":"o-1","total":{"currency":"EUR","cents":"1999"},"lines":[{"sku":"mug","quantity":2}]}`
+			data, err := serializer.Serialize("orders", ValuePart, text)
+			if err != nil {
+				t.Fatalf("Serialize: %v", err)
+			}
+			got, err := d.Deserialize("orders", data)
+			if err != nil {
+				t.Fatalf("Deserialize: %v", err)
+			}
+			if got != text {
+				t.Errorf("round trip:\ngot  %s\nwant %s", got, text)
+			}
+
+			if !detector.Detect("orders", data) {
+				t.Error("Detect: rejected a shop.Order")
+			}
+			// Field 15, a varint, isn't in shop.Order.
+			if detector.Detect("orders", append(data, 15<<3, 1)) {
+				t.Error("Detect: accepted a payload with unknown fields")
+			}
+			if detector.Detect("payments", data) {
+				t.Error("Detect: accepted a topic without a type")
+			}
+			if _, err := d.Deserialize("payments", data); err == nil || !strings.Contains(err.Error(), "no protobuf type configured for topic payments") {
+				t.Errorf("Deserialize: got error %v for a topic without a type", err)
+			}
+		})
+	}
+}
+
+func TestNewProtobufErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    ProtobufOptions
+		wantErr string
+	}{
+		{
+			name:    "missing file",
+			opts:    ProtobufOptions{ImportPaths: []string{"testdata/proto"}, Files: []string{"shop/missing.proto"}, Topics: map[string]string{"orders": "shop.Order"}},
+			wantErr: "unable to compile .proto files",
+		},
+		{
+			name:    "unknown message",
+			opts:    ProtobufOptions{DescriptorSets: []string{"testdata/shop.binpb"}, Topics: map[string]string{"orders": "shop.Refund"}},
+			wantErr: "protobuf type for topic orders: message shop.Refund not found",
+		},
+		{
+			name:    "not a message",
+			opts:    ProtobufOptions{DescriptorSets: []string{"testdata/shop.binpb"}, Topics: map[string]string{"orders": "shop.Order.lines"}},
+			wantErr: "shop.Order.lines is not a message",
+		},
+		{
+			name:    "not a descriptor set",
+			opts:    ProtobufOptions{DescriptorSets: []string{"testdata/proto/shop/order.proto"}, Topics: map[string]string{"orders": "shop.Order"}},
+			wantErr: "is not a FileDescriptorSet",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewProtobuf(nil, tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("NewProtobuf: got error %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package serde
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/sr"
+)
+
+// retryLookupAfter is how long a failed schema lookup is remembered, so a
+// topic full of records with an unknown ID doesn't hit the registry once
+// per record.
+const retryLookupAfter = 30 * time.Second
+
+var errNoWireHeader = errors.New("no schema registry header (magic byte 0 and a schema ID)")
+
+// SchemaRegistryOptions locates a Confluent compatible schema registry.
+type SchemaRegistryOptions struct {
+	URL      string
+	Username string
+	Password string
+}
+
+// SchemaRegistry fetches the schemas that records in Confluent's wire
+// format point to, and caches them by ID for the rest of the session.
+type SchemaRegistry struct {
+	client *sr.Client
+
+	mu          sync.Mutex
+	byID        map[int]schemaLookup
+	byReference map[sr.SchemaReference]schemaLookup
+}
+
+// namedSchema is a referenced schema under the name the referencing schema
+// uses for it, such as an import path for protobuf.
+type namedSchema struct {
+	name   string
+	schema sr.Schema
+}
+
+type schemaLookup struct {
+	schema sr.Schema
+	err    error
+	at     time.Time
+}
+
+func NewSchemaRegistry(opts SchemaRegistryOptions) (*SchemaRegistry, error) {
+	clientOpts := []sr.ClientOpt{sr.URLs(opts.URL), sr.UserAgent("lazykafka")}
+	if opts.Username != "" {
+		clientOpts = append(clientOpts, sr.BasicAuth(opts.Username, opts.Password))
+	}
+	client, err := sr.NewClient(clientOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create schema registry client: %w", err)
+	}
+	return &SchemaRegistry{
+		client:      client,
+		byID:        make(map[int]schemaLookup),
+		byReference: make(map[sr.SchemaReference]schemaLookup),
+	}, nil
+}
+
+// schemaByID returns the schema registered under id.
+func (r *SchemaRegistry) schemaByID(id int) (sr.Schema, error) {
+	return lookup(r, r.byID, id, func(ctx context.Context) (sr.Schema, error) {
+		s, err := r.client.SchemaByID(ctx, id)
+		if err != nil {
+			return s, fmt.Errorf("schema %d: %w", id, err)
+		}
+		return s, nil
+	})
+}
+
+// latest returns the newest schema registered under subject. It is looked
+// up every time, so producing picks up new versions, and remembered by ID
+// for decoding.
+func (r *SchemaRegistry) latest(subject string) (sr.SubjectSchema, error) {
+	ss, err := r.client.SchemaByVersion(context.Background(), subject, -1)
+	if err != nil {
+		return ss, fmt.Errorf("subject %s: %w", subject, err)
+	}
+
+	r.mu.Lock()
+	r.byID[ss.ID] = schemaLookup{schema: ss.Schema, at: time.Now()}
+	r.mu.Unlock()
+	return ss, nil
+}
+
+// references returns every schema s refers to, directly or not, with each
+// one ahead of the schemas that refer to it, so they can be parsed in order.
+func (r *SchemaRegistry) references(s sr.Schema) ([]namedSchema, error) {
+	var (
+		ordered []namedSchema
+		seen    = make(map[sr.SchemaReference]bool)
+		visit   func(refs []sr.SchemaReference) error
+	)
+	visit = func(refs []sr.SchemaReference) error {
+		for _, ref := range refs {
+			key := sr.SchemaReference{Subject: ref.Subject, Version: ref.Version}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			referenced, err := lookup(r, r.byReference, key, func(ctx context.Context) (sr.Schema, error) {
+				ss, err := r.client.SchemaByVersion(ctx, ref.Subject, ref.Version)
+				if err != nil {
+					return sr.Schema{}, fmt.Errorf("reference %s version %d: %w", ref.Subject, ref.Version, err)
+				}
+				return ss.Schema, nil
+			})
+			if err != nil {
+				return err
+			}
+			if err := visit(referenced.References); err != nil {
+				return err
+			}
+			ordered = append(ordered, namedSchema{name: ref.Name, schema: referenced})
+		}
+		return nil
+	}
+	return ordered, visit(s.References)
+}
+
+// lookup returns the cached schema for key, fetching it when it isn't
+// cached or an earlier attempt failed long enough ago. Lookups are
+// serialised, so concurrent readers of the same ID share one request.
+func lookup[K comparable](r *SchemaRegistry, cache map[K]schemaLookup, key K, fetch func(context.Context) (sr.Schema, error)) (sr.Schema, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if l, ok := cache[key]; ok && (l.err == nil || time.Since(l.at) < retryLookupAfter) {
+		return l.schema, l.err
+	}
+	s, err := fetch(context.Background())
+	cache[key] = schemaLookup{schema: s, err: err, at: time.Now()}
+	return s, err
+}
+
+// hasSchema reports whether data carries the header of a schema of type
+// typ, looking the schema up if need be. Any payload starting with a zero
+// byte has such a header, big-endian integer keys included; the IDs those
+// make up are not found, and so only asked for once per retryLookupAfter.
+func (r *SchemaRegistry) hasSchema(data []byte, typ sr.SchemaType) bool {
+	id, _, err := splitWireHeader(data)
+	if err != nil {
+		return false
+	}
+	s, err := r.schemaByID(id)
+	return err == nil && s.Type == typ
+}
+
+// hasWireHeader reports whether data starts like a record serialized
+// against a schema registry: a zero magic byte and a four byte schema ID.
+func hasWireHeader(data []byte) bool {
+	return len(data) >= 5 && data[0] == 0
+}
+
+// splitWireHeader returns the schema ID data was serialized with and the
+// payload that follows it.
+func splitWireHeader(data []byte) (int, []byte, error) {
+	var header sr.ConfluentHeader
+	id, payload, err := header.DecodeID(data)
+	if err != nil {
+		return 0, nil, errNoWireHeader
+	}
+	return id, payload, nil
+}
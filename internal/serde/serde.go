@@ -0,0 +1,175 @@
+// Package serde turns record keys and values into text for display, search
+// and download, and text typed in by the user into keys and values to
+// produce.
+package serde
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// Auto is the deserializer that guesses each payload's format.
+	Auto = "auto"
+	// Null is the serializer that produces a null key, or a null value
+	// to delete the key from a compacted topic.
+	Null = "null"
+)
+
+// Deserializer decodes a non-nil key or value read from topic.
+type Deserializer interface {
+	Name() string
+	Deserialize(topic string, data []byte) (string, error)
+}
+
+// Serializer encodes text typed in by the user as a key or value to
+// produce to topic.
+type Serializer interface {
+	Name() string
+	Serialize(topic string, part Part, text string) ([]byte, error)
+}
+
+// Part is the half of a record being encoded. Schema registry subjects are
+// named after the topic and the part, e.g. orders-value.
+type Part string
+
+const (
+	KeyPart   Part = "key"
+	ValuePart Part = "value"
+)
+
+func (p Part) subject(topic string) string {
+	return topic + "-" + string(p)
+}
+
+// Detector is implemented by deserializers that can recognise their own
+// format, which makes them candidates for auto detection.
+type Detector interface {
+	Detect(topic string, data []byte) bool
+}
+
+// Registry holds the deserializers that can be picked by name.
+type Registry struct {
+	byName map[string]Deserializer
+	names  []string
+}
+
+// NewRegistry returns a registry with auto detection and the built-in
+// deserializers.
+func NewRegistry() *Registry {
+	r := &Registry{byName: make(map[string]Deserializer)}
+	r.Register(auto{r})
+	for _, d := range builtins {
+		r.Register(d)
+	}
+	return r
+}
+
+// Register adds d, replacing any deserializer with the same name. Auto
+// detection tries the most recently registered detectors first, so more
+// specific formats should be registered after general ones.
+func (r *Registry) Register(d Deserializer) {
+	if _, ok := r.byName[d.Name()]; !ok {
+		r.names = append(r.names, d.Name())
+	}
+	r.byName[d.Name()] = d
+}
+
+// Names lists the deserializers in the order they were registered.
+func (r *Registry) Names() []string {
+	return append([]string(nil), r.names...)
+}
+
+// Get returns the named deserializer, or auto detection for a name it
+// doesn't know.
+func (r *Registry) Get(name string) Deserializer {
+	if d, ok := r.byName[name]; ok {
+		return d
+	}
+	return r.byName[Auto]
+}
+
+// Serializers lists the registered deserializers that can also encode,
+// in the order they were registered, followed by null.
+func (r *Registry) Serializers() []string {
+	var names []string
+	for _, name := range r.names {
+		if _, ok := r.byName[name].(Serializer); ok {
+			names = append(names, name)
+		}
+	}
+	return append(names, Null)
+}
+
+// Serializer returns the named serializer.
+func (r *Registry) Serializer(name string) (Serializer, error) {
+	if name == Null {
+		return tombstone{}, nil
+	}
+	if s, ok := r.byName[name].(Serializer); ok {
+		return s, nil
+	}
+	return nil, fmt.Errorf("unknown serializer %q (expected one of %s)", name, strings.Join(r.Serializers(), ", "))
+}
+
+// auto picks the first detector that recognises a payload and decodes it,
+// and falls back to a hex dump.
+type auto struct {
+	r *Registry
+}
+
+func (auto) Name() string { return Auto }
+
+func (a auto) Deserialize(topic string, data []byte) (string, error) {
+	for i := len(a.r.names) - 1; i >= 0; i-- {
+		d := a.r.byName[a.r.names[i]]
+		detector, ok := d.(Detector)
+		if !ok || !detector.Detect(topic, data) {
+			continue
+		}
+		if text, err := d.Deserialize(topic, data); err == nil {
+			return text, nil
+		}
+	}
+	return hexDump{}.Deserialize(topic, data)
+}
+
+// Decode deserializes data with d, naming d in the error if it fails.
+func Decode(d Deserializer, topic string, data []byte) (string, error) {
+	text, err := d.Deserialize(topic, data)
+	if err != nil {
+		return "", fmt.Errorf("cannot decode as %s: %w", d.Name(), err)
+	}
+	return text, nil
+}
+
+// Encode serializes text with s, naming the part and s in the error if it
+// fails.
+func Encode(s Serializer, topic string, part Part, text string) ([]byte, error) {
+	data, err := s.Serialize(topic, part, text)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode %s as %s: %w", part, s.Name(), err)
+	}
+	return data, nil
+}
+
+// escapeControl makes control characters other than newlines and tabs
+// visible, so text can't move the cursor or garble the terminal.
+func escapeControl(s string) string {
+	if !strings.ContainsFunc(s, isControl) {
+		return s
+	}
+	var b strings.Builder
+	for _, r := range s {
+		if isControl(r) {
+			fmt.Fprintf(&b, "\\x%02x", r)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func isControl(r rune) bool {
+	return r != '\n' && r != '\t' && (r < 0x20 || r == 0x7f || r >= 0x80 && r < 0xa0)
+}
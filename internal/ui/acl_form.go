@@ -0,0 +1,234 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var aclFormLabels = []string{
+	"Principal",
+	"Host",
+	"Resource Type",
+	"Resource Name",
+	"Pattern Type",
+	"Operations",
+	"Permission",
+}
+
+var aclCreatePlaceholders = []string{
+	"User:alice",
+	"* (any host)",
+	"topic, group, cluster, transactional-id, delegation-token",
+	"orders",
+	"literal or prefixed",
+	"read,write,describe",
+	"allow or deny",
+}
+
+const (
+	aclPrincipal = iota
+	aclHost
+	aclResourceType
+	aclResourceName
+	aclPattern
+	aclOperations
+	aclPermission
+)
+
+// ACLForm collects the fields of an ACL. When deleting, the fields form a
+// filter and any left empty match everything.
+type ACLForm struct {
+	deleting bool
+	inputs   []textinput.Model
+	focused  int
+	err      string
+}
+
+type ACLSubmittedMsg struct {
+	Delete bool
+	Filter ACLFilter
+}
+
+func NewCreateACLForm() ACLForm {
+	f := newACLForm(false, aclCreatePlaceholders)
+	f.inputs[aclHost].SetValue("*")
+	f.inputs[aclResourceType].SetValue("topic")
+	f.inputs[aclPattern].SetValue("literal")
+	f.inputs[aclPermission].SetValue("allow")
+	return f
+}
+
+// NewDeleteACLForm starts from the given filter, usually the binding under
+// the cursor, so deleting a single ACL is just a confirmation away.
+func NewDeleteACLForm(filter ACLFilter) ACLForm {
+	placeholders := make([]string, len(aclFormLabels))
+	for i := range placeholders {
+		placeholders[i] = "any"
+	}
+	f := newACLForm(true, placeholders)
+	f.inputs[aclPrincipal].SetValue(filter.Principal)
+	f.inputs[aclHost].SetValue(filter.Host)
+	f.inputs[aclResourceType].SetValue(filter.ResourceType)
+	f.inputs[aclResourceName].SetValue(filter.ResourceName)
+	f.inputs[aclPattern].SetValue(filter.Pattern)
+	f.inputs[aclOperations].SetValue(strings.Join(filter.Operations, ","))
+	f.inputs[aclPermission].SetValue(filter.Permission)
+	return f
+}
+
+func newACLForm(deleting bool, placeholders []string) ACLForm {
+	inputs := make([]textinput.Model, len(aclFormLabels))
+	for i := range inputs {
+		input := textinput.New()
+		input.Placeholder = placeholders[i]
+		input.Width = 56
+		inputs[i] = input
+	}
+	inputs[0].Focus()
+	return ACLForm{deleting: deleting, inputs: inputs}
+}
+
+func (f ACLForm) Init() tea.Cmd { return textinput.Blink }
+
+func (f ACLForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "tab", "shift+tab":
+			f.inputs[f.focused].Blur()
+			if msg.String() == "tab" {
+				f.focused = (f.focused + 1) % len(f.inputs)
+			} else {
+				f.focused = (f.focused - 1 + len(f.inputs)) % len(f.inputs)
+			}
+			return f, f.inputs[f.focused].Focus()
+		case "enter":
+			filter, err := f.values()
+			if err != nil {
+				f.err = err.Error()
+				return f, nil
+			}
+			f.err = ""
+			return f, func() tea.Msg { return ACLSubmittedMsg{Delete: f.deleting, Filter: filter} }
+		case "esc":
+			return f, nil
+		}
+	}
+
+	f.inputs[f.focused], cmd = f.inputs[f.focused].Update(msg)
+	return f, cmd
+}
+
+// values checks what the form can on its own; the enum names are checked
+// when the request is built.
+func (f ACLForm) values() (ACLFilter, error) {
+	value := func(i int) string { return strings.TrimSpace(f.inputs[i].Value()) }
+
+	filter := ACLFilter{
+		Principal:    value(aclPrincipal),
+		Host:         value(aclHost),
+		ResourceType: value(aclResourceType),
+		ResourceName: value(aclResourceName),
+		Pattern:      value(aclPattern),
+		Permission:   value(aclPermission),
+	}
+	filter.Operations = strings.FieldsFunc(value(aclOperations), func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+
+	if f.deleting {
+		return filter, nil
+	}
+
+	if filter.Principal == "" {
+		return filter, fmt.Errorf("principal is required")
+	}
+	if !strings.Contains(filter.Principal, ":") {
+		return filter, fmt.Errorf("principal must be in type:name form, e.g. User:%s", filter.Principal)
+	}
+	if filter.ResourceName == "" && !strings.EqualFold(filter.ResourceType, "cluster") {
+		return filter, fmt.Errorf("resource name is required")
+	}
+	if len(filter.Operations) == 0 {
+		return filter, fmt.Errorf("at least one operation is required")
+	}
+	return filter, nil
+}
+
+// SetError shows an error returned while building or sending the request,
+// keeping what was typed so it can be corrected.
+func (f *ACLForm) SetError(err error) {
+	f.err = err.Error()
+}
+
+func (f ACLForm) View() string {
+	title := FormTitleStyle.Render("Create ACL")
+	help := "enter: create • tab: next field • esc: cancel"
+	if f.deleting {
+		title = FormTitleStyle.Render("Delete ACLs")
+		help = "enter: preview matches • tab: next field • esc: cancel"
+	}
+
+	parts := []string{title}
+	if f.deleting {
+		parts = append(parts, lipgloss.NewStyle().Foreground(SubtleColor).Render("Empty fields match any value."))
+	}
+	for i, input := range f.inputs {
+		parts = append(parts, aclFormLabels[i]+":", input.View())
+	}
+	if f.err != "" {
+		parts = append(parts, "", FormErrorStyle.Render("✗ "+f.err))
+	}
+	parts = append(parts, FormHelpStyle.Render(help))
+
+	return FormBoxStyle.Width(70).Render(lipgloss.JoinVertical(lipgloss.Left, parts...))
+}
+
+type DeleteACLsForm struct {
+	matches []ACLBinding
+}
+
+type ACLsDeleteMsg struct {
+	Confirmed bool
+}
+
+func NewDeleteACLsForm(matches []ACLBinding) DeleteACLsForm {
+	return DeleteACLsForm{matches: matches}
+}
+
+func (f DeleteACLsForm) Init() tea.Cmd { return nil }
+
+func (f DeleteACLsForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "y", "Y":
+			return f, func() tea.Msg { return ACLsDeleteMsg{Confirmed: true} }
+		case "n", "N", "esc":
+			return f, func() tea.Msg { return ACLsDeleteMsg{Confirmed: false} }
+		}
+	}
+	return f, nil
+}
+
+func (f DeleteACLsForm) View() string {
+	items := make([]string, len(f.matches))
+	for i, b := range f.matches {
+		items[i] = b.String()
+	}
+	noun := "binding"
+	if len(f.matches) != 1 {
+		noun = "bindings"
+	}
+	return renderDeleteConfirm(
+		FormBoxStyle.Width(80),
+		"Delete ACLs",
+		fmt.Sprintf("The filter matches %d %s. Delete them?", len(f.matches), noun),
+		items,
+	)
+}
@@ -0,0 +1,227 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ACLBinding is one ACL as the broker reports it. The enum fields hold the
+// protocol names, e.g. TOPIC, LITERAL, READ and ALLOW.
+type ACLBinding struct {
+	Principal    string
+	Host         string
+	ResourceType string
+	ResourceName string
+	Pattern      string
+	Operation    string
+	Permission   string
+}
+
+func (b ACLBinding) String() string {
+	return fmt.Sprintf("%s %s from %s: %s on %s %q (%s)",
+		b.Permission, b.Principal, b.Host, b.Operation, b.ResourceType, b.ResourceName, b.Pattern)
+}
+
+// ACLFilter describes the bindings to create or, when deleting, the ones to
+// match. Empty fields match anything when deleting.
+type ACLFilter struct {
+	Principal    string
+	Host         string
+	ResourceType string
+	ResourceName string
+	Pattern      string
+	Operations   []string
+	Permission   string
+}
+
+type ACLCreateRequestedMsg struct{}
+
+// ACLDeleteRequestedMsg asks for the delete form, prefilled to match the
+// binding under the cursor.
+type ACLDeleteRequestedMsg struct {
+	Filter ACLFilter
+}
+
+type ACLsViewModel struct {
+	acls    []ACLBinding
+	visible []ACLBinding
+	loaded  bool
+	err     error
+
+	table       table.Model
+	filtering   bool
+	filterInput textinput.Model
+
+	width  int
+	height int
+}
+
+func NewACLsViewModel(width, height int) *ACLsViewModel {
+	filterInput := textinput.New()
+	filterInput.Placeholder = "Filter ACLs..."
+	filterInput.Width = 40
+
+	vm := &ACLsViewModel{
+		table:       newTable(nil),
+		filterInput: filterInput,
+	}
+	vm.resize(width, height)
+	return vm
+}
+
+func (v *ACLsViewModel) SetACLs(acls []ACLBinding, err error) {
+	v.loaded = true
+	v.err = err
+	if err == nil {
+		v.acls = acls
+	}
+	v.refreshRows()
+}
+
+// Typing reports whether keys are going to a text input, so the parent
+// should not treat them as shortcuts.
+func (v *ACLsViewModel) Typing() bool {
+	return v.filtering
+}
+
+func (v *ACLsViewModel) resize(width, height int) {
+	v.width = width
+	v.height = height
+	v.table.SetColumns(fitColumns([]table.Column{
+		{Title: "Principal", Width: 24},
+		{Title: "Host", Width: 15},
+		{Title: "Resource Type", Width: 16},
+		{Title: "Pattern", Width: 8},
+		{Title: "Resource Name", Width: 0},
+		{Title: "Operation", Width: 16},
+		{Title: "Permission", Width: 10},
+	}, width, 4))
+	v.table.SetHeight(max(height-6, 3))
+}
+
+// matches reports whether every word of the filter appears in one of the
+// binding's fields, so "alice read" narrows to alice's read grants.
+func (b ACLBinding) matches(filter string) bool {
+	fields := strings.ToLower(strings.Join([]string{
+		b.Principal, b.Host, b.ResourceType, b.ResourceName, b.Pattern, b.Operation, b.Permission,
+	}, "\x00"))
+	for _, word := range strings.Fields(filter) {
+		if !strings.Contains(fields, word) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *ACLsViewModel) refreshRows() {
+	filter := strings.ToLower(v.filterInput.Value())
+
+	v.visible = v.visible[:0]
+	rows := make([]table.Row, 0, len(v.acls))
+	for _, b := range v.acls {
+		if !b.matches(filter) {
+			continue
+		}
+		v.visible = append(v.visible, b)
+		rows = append(rows, table.Row{
+			b.Principal, b.Host, b.ResourceType, b.Pattern, b.ResourceName, b.Operation, b.Permission,
+		})
+	}
+	v.table.SetRows(rows)
+	if v.table.Cursor() >= len(rows) {
+		v.table.SetCursor(max(len(rows)-1, 0))
+	}
+}
+
+func (v *ACLsViewModel) selected() (ACLBinding, bool) {
+	cursor := v.table.Cursor()
+	if cursor < 0 || cursor >= len(v.visible) {
+		return ACLBinding{}, false
+	}
+	return v.visible[cursor], true
+}
+
+func (v *ACLsViewModel) Init() tea.Cmd {
+	return nil
+}
+
+func (v *ACLsViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.resize(msg.Width, msg.Height)
+		return v, nil
+
+	case tea.KeyMsg:
+		if v.filtering {
+			switch msg.String() {
+			case "enter", "esc":
+				if msg.String() == "esc" {
+					v.filterInput.SetValue("")
+				}
+				v.filtering = false
+				v.filterInput.Blur()
+				v.refreshRows()
+				return v, nil
+			}
+			v.filterInput, cmd = v.filterInput.Update(msg)
+			v.refreshRows()
+			return v, cmd
+		}
+
+		switch msg.String() {
+		case "/":
+			v.filtering = true
+			return v, v.filterInput.Focus()
+		case "r":
+			return v, func() tea.Msg { return RefreshMsg{} }
+		case "c":
+			return v, func() tea.Msg { return ACLCreateRequestedMsg{} }
+		case "x":
+			var filter ACLFilter
+			if b, ok := v.selected(); ok {
+				filter = ACLFilter{
+					Principal:    b.Principal,
+					Host:         b.Host,
+					ResourceType: b.ResourceType,
+					ResourceName: b.ResourceName,
+					Pattern:      b.Pattern,
+					Operations:   []string{b.Operation},
+					Permission:   b.Permission,
+				}
+			}
+			return v, func() tea.Msg { return ACLDeleteRequestedMsg{Filter: filter} }
+		}
+	}
+
+	v.table, cmd = v.table.Update(msg)
+	return v, cmd
+}
+
+func (v *ACLsViewModel) View() string {
+	var statusBar string
+	switch {
+	case v.filtering:
+		statusBar = lipgloss.NewStyle().Foreground(AccentColor).Render("Filter: ") + v.filterInput.View()
+	case !v.loaded:
+		statusBar = lipgloss.NewStyle().Foreground(SubtleColor).Render("⏳ Loading ACLs...")
+	case v.err != nil:
+		statusBar = partitionErrorStyle.Render(fmt.Sprintf("Failed to load ACLs: %v", v.err))
+	case v.filterInput.Value() != "":
+		statusBar = lipgloss.NewStyle().Foreground(SubtleColor).Render(
+			fmt.Sprintf("🔍 Filter: '%s' • %d of %d bindings", v.filterInput.Value(), len(v.visible), len(v.acls)))
+	default:
+		statusBar = lipgloss.NewStyle().Foreground(SubtleColor).Render(fmt.Sprintf("%d bindings", len(v.acls)))
+	}
+
+	if v.loaded && v.err == nil && len(v.acls) == 0 {
+		return lipgloss.JoinVertical(lipgloss.Left, statusBar, "", emptyStateStyle.Render("No ACLs are defined on this cluster."))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, statusBar, v.table.View())
+}
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type AddPartitionsForm struct {
+	topicName string
+	current   int
+	textInput textinput.Model
+	err       string
+}
+
+type PartitionsSubmittedMsg struct {
+	TopicName string
+	Total     int
+}
+
+func NewAddPartitionsForm(topicName string, current int) AddPartitionsForm {
+	totalInput := textinput.New()
+	totalInput.Placeholder = fmt.Sprintf("more than %d", current)
+	totalInput.Focus()
+	totalInput.CharLimit = 10
+	totalInput.Width = 44
+	return AddPartitionsForm{
+		topicName: topicName,
+		current:   current,
+		textInput: totalInput,
+	}
+}
+
+func (f AddPartitionsForm) Init() tea.Cmd { return textinput.Blink }
+func (f AddPartitionsForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			total, err := strconv.Atoi(f.textInput.Value())
+			if err != nil || total <= f.current {
+				f.err = fmt.Sprintf("new total must be a number greater than %d", f.current)
+				return f, nil
+			}
+			f.err = ""
+			return f, func() tea.Msg {
+				return PartitionsSubmittedMsg{TopicName: f.topicName, Total: total}
+			}
+		case "esc":
+			return f, nil
+		}
+	}
+
+	f.textInput, cmd = f.textInput.Update(msg)
+	return f, cmd
+}
+
+func (f AddPartitionsForm) View() string {
+	title := FormTitleStyle.Render("Add Partitions")
+	current := fmt.Sprintf("Topic: %s\nCurrent partitions: %d", f.topicName, f.current)
+	warning := lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Width(46).Render(
+		"⚠ If this topic's records are keyed, existing keys will map to " +
+			"different partitions, as keys are assigned by hash modulo the " +
+			"partition count, and per-key ordering breaks across the change. " +
+			"Partitions cannot be removed later.")
+
+	parts := []string{title, current, "", warning, "", "New total:", f.textInput.View()}
+	if f.err != "" {
+		parts = append(parts, "", FormErrorStyle.Render("✗ "+f.err))
+	}
+	parts = append(parts, FormHelpStyle.Render("enter: add • esc: cancel"))
+
+	return FormBoxStyle.Render(lipgloss.JoinVertical(lipgloss.Left, parts...))
+}
@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// brokerImbalanceThreshold is how far, as a fraction of the even share, a
+// broker's load may drift before it is highlighted.
+const brokerImbalanceThreshold = 0.2
+
+type BrokerDetailViewModel struct {
+	nodeID  int32
+	broker  BrokerInfo
+	cluster ClusterInfo
+	loaded  bool
+	err     error
+
+	table table.Model
+
+	width  int
+	height int
+}
+
+func NewBrokerDetailViewModel(nodeID int32, width, height int) *BrokerDetailViewModel {
+	vm := &BrokerDetailViewModel{
+		nodeID: nodeID,
+		table:  newTable(nil),
+	}
+	vm.resize(width, height)
+	return vm
+}
+
+// SetCluster picks this view's broker out of a cluster listing.
+func (v *BrokerDetailViewModel) SetCluster(cluster ClusterInfo, err error) {
+	v.loaded = true
+	v.err = err
+	if err != nil {
+		return
+	}
+
+	v.cluster = cluster
+	v.err = fmt.Errorf("broker %d is no longer in the cluster", v.nodeID)
+	for _, b := range cluster.Brokers {
+		if b.NodeID == v.nodeID {
+			v.broker = b
+			v.err = nil
+		}
+	}
+	v.refreshRows()
+}
+
+func (v *BrokerDetailViewModel) resize(width, height int) {
+	v.width = width
+	v.height = height
+	v.table.SetColumns(fitColumns([]table.Column{
+		{Title: "Topic", Width: 0},
+		{Title: "Leaders", Width: 10},
+		{Title: "Replicas", Width: 10},
+	}, width, 0))
+	v.table.SetHeight(max(height-12, 3))
+}
+
+func (v *BrokerDetailViewModel) refreshRows() {
+	rows := make([]table.Row, 0, len(v.broker.Topics))
+	for _, t := range v.broker.Topics {
+		rows = append(rows, table.Row{t.Topic, fmt.Sprintf("%d", t.Leaders), fmt.Sprintf("%d", t.Replicas)})
+	}
+	v.table.SetRows(rows)
+	if v.table.Cursor() >= len(rows) {
+		v.table.SetCursor(max(len(rows)-1, 0))
+	}
+}
+
+func (v *BrokerDetailViewModel) Init() tea.Cmd {
+	return nil
+}
+
+func (v *BrokerDetailViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.resize(msg.Width, msg.Height)
+		return v, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			return v, func() tea.Msg { return BackMsg{} }
+		case "r":
+			return v, func() tea.Msg { return RefreshMsg{} }
+		case "C":
+			id := v.nodeID
+			return v, func() tea.Msg { return BrokerConfigsRequestedMsg{NodeID: id} }
+		}
+	}
+
+	v.table, cmd = v.table.Update(msg)
+	return v, cmd
+}
+
+// renderShare describes a broker's share of some load against what it would
+// carry if the load were spread evenly.
+func renderShare(label string, count, total, brokers int) string {
+	line := fmt.Sprintf("%-12s %d of %d", label, count, total)
+	if total == 0 || brokers == 0 {
+		return messageValueStyle.Render(line)
+	}
+
+	even := float64(total) / float64(brokers)
+	line += fmt.Sprintf(" (%.1f%%) • even share %.1f", 100*float64(count)/float64(total), even)
+
+	drift := (float64(count) - even) / even
+	if drift > brokerImbalanceThreshold || drift < -brokerImbalanceThreshold {
+		return partitionWarnStyle.Render(line + fmt.Sprintf(" • %+.0f%% imbalance", 100*drift))
+	}
+	return messageValueStyle.Render(line)
+}
+
+func (v *BrokerDetailViewModel) View() string {
+	headerText := fmt.Sprintf("🖥 broker %d", v.nodeID)
+	if v.loaded && v.err == nil {
+		headerText += " • " + v.broker.Address()
+		if v.broker.Rack != "" {
+			headerText += " • rack " + v.broker.Rack
+		}
+		if v.broker.Controller {
+			headerText += " • controller"
+		}
+	}
+	header := HeaderStyle.Width(v.width).Render(headerText)
+
+	var body string
+	switch {
+	case !v.loaded:
+		body = emptyStateStyle.Render("⏳ Loading broker...")
+	case v.err != nil:
+		body = partitionErrorStyle.Render(v.err.Error())
+	default:
+		brokers := len(v.cluster.Brokers)
+		body = lipgloss.JoinVertical(lipgloss.Left,
+			renderShare("Leadership", v.broker.Leaders, v.cluster.Partitions, brokers),
+			renderShare("Replicas", v.broker.Replicas, v.cluster.Replicas, brokers),
+			"",
+			partitionHeaderStyle.Render("Per topic"),
+			v.table.View(),
+		)
+	}
+
+	help := HelpStyle.Render("↑/↓ j/k: navigate • C: configs • r: refresh • esc: back")
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, body, help)
+}
@@ -0,0 +1,168 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type BrokerTopicLoad struct {
+	Topic    string
+	Leaders  int
+	Replicas int
+}
+
+type BrokerInfo struct {
+	NodeID     int32
+	Host       string
+	Port       int32
+	Rack       string // empty when the broker has no rack configured
+	Controller bool
+	Leaders    int
+	Replicas   int
+	Topics     []BrokerTopicLoad
+}
+
+func (b BrokerInfo) Address() string {
+	return fmt.Sprintf("%s:%d", b.Host, b.Port)
+}
+
+type ClusterInfo struct {
+	ClusterID  string
+	Controller int32 // -1 when unknown
+	Brokers    []BrokerInfo
+	Partitions int
+	Replicas   int
+}
+
+type BrokerSelectedMsg struct {
+	NodeID int32
+}
+
+// BrokerConfigsRequestedMsg asks for a broker's config panel, or for the
+// cluster-wide broker defaults.
+type BrokerConfigsRequestedMsg struct {
+	NodeID          int32
+	ClusterDefaults bool
+}
+
+type BrokersViewModel struct {
+	cluster ClusterInfo
+	loaded  bool
+	err     error
+
+	table table.Model
+
+	width  int
+	height int
+}
+
+func NewBrokersViewModel(width, height int) *BrokersViewModel {
+	vm := &BrokersViewModel{
+		table: newTable(nil),
+	}
+	vm.resize(width, height)
+	return vm
+}
+
+func (v *BrokersViewModel) SetCluster(cluster ClusterInfo, err error) {
+	v.loaded = true
+	v.err = err
+	if err == nil {
+		v.cluster = cluster
+	}
+	v.refreshRows()
+}
+
+func (v *BrokersViewModel) resize(width, height int) {
+	v.width = width
+	v.height = height
+	v.table.SetColumns(fitColumns([]table.Column{
+		{Title: "Node", Width: 6},
+		{Title: "Host:Port", Width: 0},
+		{Title: "Rack", Width: 16},
+		{Title: "Controller", Width: 10},
+	}, width, 1))
+	v.table.SetHeight(max(height-6, 3))
+}
+
+func (v *BrokersViewModel) refreshRows() {
+	rows := make([]table.Row, 0, len(v.cluster.Brokers))
+	for _, b := range v.cluster.Brokers {
+		rack := b.Rack
+		if rack == "" {
+			rack = "-"
+		}
+		controller := ""
+		if b.Controller {
+			controller = "★ yes"
+		}
+		rows = append(rows, table.Row{fmt.Sprintf("%d", b.NodeID), b.Address(), rack, controller})
+	}
+	v.table.SetRows(rows)
+	if v.table.Cursor() >= len(rows) {
+		v.table.SetCursor(max(len(rows)-1, 0))
+	}
+}
+
+func (v *BrokersViewModel) Init() tea.Cmd {
+	return nil
+}
+
+func (v *BrokersViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.resize(msg.Width, msg.Height)
+		return v, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "r":
+			return v, func() tea.Msg { return RefreshMsg{} }
+		case "enter", "C":
+			cursor := v.table.Cursor()
+			if cursor < 0 || cursor >= len(v.cluster.Brokers) {
+				return v, nil
+			}
+			id := v.cluster.Brokers[cursor].NodeID
+			if msg.String() == "C" {
+				return v, func() tea.Msg { return BrokerConfigsRequestedMsg{NodeID: id} }
+			}
+			return v, func() tea.Msg { return BrokerSelectedMsg{NodeID: id} }
+		case "D":
+			return v, func() tea.Msg { return BrokerConfigsRequestedMsg{ClusterDefaults: true} }
+		}
+	}
+
+	v.table, cmd = v.table.Update(msg)
+	return v, cmd
+}
+
+func (v *BrokersViewModel) View() string {
+	subtle := lipgloss.NewStyle().Foreground(SubtleColor)
+
+	var statusBar string
+	switch {
+	case !v.loaded:
+		statusBar = subtle.Render("⏳ Loading brokers...")
+	case v.err != nil:
+		statusBar = partitionErrorStyle.Render(fmt.Sprintf("Failed to load brokers: %v", v.err))
+	default:
+		clusterID := v.cluster.ClusterID
+		if clusterID == "" {
+			clusterID = "unknown"
+		}
+		controller := "unknown"
+		if v.cluster.Controller >= 0 {
+			controller = fmt.Sprintf("%d", v.cluster.Controller)
+		}
+		statusBar = subtle.Render(fmt.Sprintf("Cluster %s • controller %s • %d brokers • %d partitions",
+			clusterID, controller, len(v.cluster.Brokers), v.cluster.Partitions))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, statusBar, v.table.View())
+}
@@ -0,0 +1,396 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	overlay "github.com/rmhubbert/bubbletea-overlay"
+)
+
+type ConfigEntry struct {
+	Key       string
+	Value     *string // nil for sensitive or unset keys
+	Source    string
+	Sensitive bool
+	// Default is set when the value is inherited rather than set on the
+	// resource itself, in which case there is nothing to reset.
+	Default bool
+}
+
+// ConfigChange is a pending edit. A nil New resets the key to its default.
+type ConfigChange struct {
+	Key       string
+	Old       *string
+	New       *string
+	Sensitive bool
+}
+
+type ConfigChangesConfirmedMsg struct {
+	Changes []ConfigChange
+}
+
+// BackMsg asks the parent to leave the current view.
+type BackMsg struct{}
+
+type configMode int
+
+const (
+	configBrowse configMode = iota
+	configFilter
+	configEdit
+	configAdd
+	configConfirm
+)
+
+var (
+	configOldValueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	configNewValueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	configStatusStyle   = lipgloss.NewStyle().Foreground(SubtleColor).Padding(0, 1)
+)
+
+type ConfigViewModel struct {
+	title   string
+	entries []ConfigEntry
+	visible []ConfigEntry
+	pending map[string]ConfigChange
+	loaded  bool
+
+	table       table.Model
+	mode        configMode
+	filterInput textinput.Model
+	editInput   textinput.Model
+	addInput    textinput.Model
+	status      string
+
+	width  int
+	height int
+}
+
+func NewConfigViewModel(title string, width, height int) *ConfigViewModel {
+	filterInput := textinput.New()
+	filterInput.Placeholder = "Filter keys..."
+	filterInput.Width = 40
+
+	editInput := textinput.New()
+	editInput.Width = 60
+
+	addInput := textinput.New()
+	addInput.Placeholder = "key=value"
+	addInput.Width = 60
+
+	vm := &ConfigViewModel{
+		title:       title,
+		pending:     make(map[string]ConfigChange),
+		table:       newTable(nil),
+		filterInput: filterInput,
+		editInput:   editInput,
+		addInput:    addInput,
+	}
+	vm.resize(width, height)
+	return vm
+}
+
+// SetEntries replaces the displayed configs, dropping any pending changes
+// since they were made against the previous values.
+func (v *ConfigViewModel) SetEntries(entries []ConfigEntry) {
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	v.entries = entries
+	v.pending = make(map[string]ConfigChange)
+	v.loaded = true
+	v.mode = configBrowse
+	v.refreshRows()
+}
+
+func (v *ConfigViewModel) SetStatus(status string) {
+	v.status = status
+}
+
+func (v *ConfigViewModel) resize(width, height int) {
+	v.width = width
+	v.height = height
+	v.table.SetColumns(fitColumns([]table.Column{
+		{Title: "", Width: 1},
+		{Title: "Key", Width: 42},
+		{Title: "Value", Width: 0},
+		{Title: "Source", Width: 22},
+	}, width-2, 2))
+	v.table.SetHeight(max(height-10, 3))
+}
+
+func (v *ConfigViewModel) refreshRows() {
+	filter := strings.ToLower(v.filterInput.Value())
+
+	v.visible = v.visible[:0]
+	rows := make([]table.Row, 0, len(v.entries))
+	for _, e := range v.entries {
+		if filter != "" && !strings.Contains(strings.ToLower(e.Key), filter) {
+			continue
+		}
+		v.visible = append(v.visible, e)
+
+		marker := ""
+		value := displayConfigValue(e.Value, e.Sensitive)
+		if change, ok := v.pending[e.Key]; ok {
+			marker = "*"
+			value = value + " → " + displayConfigValue(change.New, false)
+		}
+		rows = append(rows, table.Row{marker, e.Key, value, e.Source})
+	}
+	v.table.SetRows(rows)
+	if v.table.Cursor() >= len(rows) {
+		v.table.SetCursor(max(len(rows)-1, 0))
+	}
+}
+
+func displayConfigValue(value *string, sensitive bool) string {
+	switch {
+	case sensitive:
+		return "(sensitive)"
+	case value == nil:
+		return "(default)"
+	case *value == "":
+		return `""`
+	default:
+		return *value
+	}
+}
+
+// addEntry stages a value for a key, listing the key first if the resource
+// did not describe it, as happens for unset cluster-wide defaults.
+func (v *ConfigViewModel) addEntry(key, value string) {
+	var entry *ConfigEntry
+	for i := range v.entries {
+		if v.entries[i].Key == key {
+			entry = &v.entries[i]
+		}
+	}
+	if entry == nil {
+		v.entries = append(v.entries, ConfigEntry{Key: key, Source: "new", Default: true})
+		sort.Slice(v.entries, func(i, j int) bool { return v.entries[i].Key < v.entries[j].Key })
+		v.pending[key] = ConfigChange{Key: key, New: &value}
+		return
+	}
+	v.pending[key] = ConfigChange{Key: key, Old: entry.Value, New: &value, Sensitive: entry.Sensitive}
+}
+
+func (v *ConfigViewModel) selected() (ConfigEntry, bool) {
+	cursor := v.table.Cursor()
+	if cursor < 0 || cursor >= len(v.visible) {
+		return ConfigEntry{}, false
+	}
+	return v.visible[cursor], true
+}
+
+func (v *ConfigViewModel) sortedChanges() []ConfigChange {
+	changes := make([]ConfigChange, 0, len(v.pending))
+	for _, c := range v.pending {
+		changes = append(changes, c)
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
+
+func (v *ConfigViewModel) Init() tea.Cmd {
+	return nil
+}
+
+func (v *ConfigViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.resize(msg.Width, msg.Height)
+		return v, nil
+
+	case tea.KeyMsg:
+		switch v.mode {
+		case configFilter:
+			switch msg.String() {
+			case "enter", "esc":
+				if msg.String() == "esc" {
+					v.filterInput.SetValue("")
+				}
+				v.filterInput.Blur()
+				v.mode = configBrowse
+				v.refreshRows()
+				return v, nil
+			}
+			v.filterInput, cmd = v.filterInput.Update(msg)
+			v.refreshRows()
+			return v, cmd
+
+		case configEdit:
+			switch msg.String() {
+			case "enter":
+				if entry, ok := v.selected(); ok {
+					value := v.editInput.Value()
+					v.pending[entry.Key] = ConfigChange{Key: entry.Key, Old: entry.Value, New: &value, Sensitive: entry.Sensitive}
+				}
+				v.editInput.Blur()
+				v.mode = configBrowse
+				v.refreshRows()
+				return v, nil
+			case "esc":
+				v.editInput.Blur()
+				v.mode = configBrowse
+				return v, nil
+			}
+			v.editInput, cmd = v.editInput.Update(msg)
+			return v, cmd
+
+		case configAdd:
+			switch msg.String() {
+			case "enter":
+				key, value, ok := strings.Cut(v.addInput.Value(), "=")
+				key = strings.TrimSpace(key)
+				if !ok || key == "" {
+					v.status = "Enter the new config as key=value"
+					return v, nil
+				}
+				v.addEntry(key, value)
+				v.addInput.Blur()
+				v.mode = configBrowse
+				v.refreshRows()
+				return v, nil
+			case "esc":
+				v.addInput.Blur()
+				v.mode = configBrowse
+				return v, nil
+			}
+			v.addInput, cmd = v.addInput.Update(msg)
+			return v, cmd
+
+		case configConfirm:
+			switch msg.String() {
+			case "y", "Y":
+				changes := v.sortedChanges()
+				v.mode = configBrowse
+				v.status = "Applying changes..."
+				return v, func() tea.Msg {
+					return ConfigChangesConfirmedMsg{Changes: changes}
+				}
+			case "n", "N", "esc":
+				v.mode = configBrowse
+			}
+			return v, nil
+		}
+
+		v.status = ""
+		switch msg.String() {
+		case "esc":
+			return v, func() tea.Msg { return BackMsg{} }
+		case "/":
+			v.mode = configFilter
+			return v, v.filterInput.Focus()
+		case "e", "enter":
+			entry, ok := v.selected()
+			if !ok {
+				return v, nil
+			}
+			v.editInput.SetValue("")
+			if change, ok := v.pending[entry.Key]; ok && change.New != nil {
+				v.editInput.SetValue(*change.New)
+			} else if entry.Value != nil && !entry.Sensitive {
+				v.editInput.SetValue(*entry.Value)
+			}
+			v.editInput.EchoMode = textinput.EchoNormal
+			if entry.Sensitive {
+				v.editInput.EchoMode = textinput.EchoPassword
+			}
+			v.editInput.CursorEnd()
+			v.mode = configEdit
+			return v, v.editInput.Focus()
+		case "n":
+			v.addInput.SetValue("")
+			v.mode = configAdd
+			return v, v.addInput.Focus()
+		case "r":
+			entry, ok := v.selected()
+			if !ok {
+				return v, nil
+			}
+			if entry.Default {
+				v.status = fmt.Sprintf("%s is not set here; nothing to reset", entry.Key)
+				return v, nil
+			}
+			v.pending[entry.Key] = ConfigChange{Key: entry.Key, Old: entry.Value, Sensitive: entry.Sensitive}
+			v.refreshRows()
+			return v, nil
+		case "u":
+			if entry, ok := v.selected(); ok {
+				delete(v.pending, entry.Key)
+				v.refreshRows()
+			}
+			return v, nil
+		case "a":
+			if len(v.pending) == 0 {
+				v.status = "No pending changes"
+				return v, nil
+			}
+			v.mode = configConfirm
+			return v, nil
+		}
+	}
+
+	v.table, cmd = v.table.Update(msg)
+	return v, cmd
+}
+
+func (v *ConfigViewModel) confirmView() string {
+	title := FormTitleStyle.Render("Apply config changes?")
+
+	lines := []string{title}
+	for _, c := range v.sortedChanges() {
+		lines = append(lines, fmt.Sprintf("%s\n  %s → %s",
+			c.Key,
+			configOldValueStyle.Render(displayConfigValue(c.Old, c.Sensitive)),
+			configNewValueStyle.Render(displayConfigValue(c.New, c.Sensitive && c.New != nil)),
+		))
+	}
+	lines = append(lines, FormHelpStyle.Render("y: apply • n/esc: cancel"))
+
+	return FormBoxStyle.Width(70).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+func (v *ConfigViewModel) View() string {
+	headerText := fmt.Sprintf("⚙ %s", v.title)
+	if v.loaded {
+		headerText += fmt.Sprintf(" • %d keys", len(v.entries))
+	}
+	if len(v.pending) > 0 {
+		headerText += fmt.Sprintf(" • %d pending", len(v.pending))
+	}
+	header := HeaderStyle.Width(v.width).Render(headerText)
+
+	var statusBar string
+	switch {
+	case v.mode == configFilter:
+		statusBar = lipgloss.NewStyle().Foreground(AccentColor).Padding(0, 1).Render("Filter: ") + v.filterInput.View()
+	case v.mode == configEdit:
+		entry, _ := v.selected()
+		statusBar = lipgloss.NewStyle().Foreground(AccentColor).Padding(0, 1).Render(entry.Key+" = ") + v.editInput.View()
+	case v.mode == configAdd:
+		statusBar = lipgloss.NewStyle().Foreground(AccentColor).Padding(0, 1).Render("New: ") + v.addInput.View()
+	case v.status != "":
+		statusBar = configStatusStyle.Render(v.status)
+	case !v.loaded:
+		statusBar = configStatusStyle.Render("Loading configs...")
+	case v.filterInput.Value() != "":
+		statusBar = configStatusStyle.Render(fmt.Sprintf("🔍 Filter: '%s'", v.filterInput.Value()))
+	default:
+		statusBar = configStatusStyle.Render("")
+	}
+
+	help := HelpStyle.Render("↑/↓ j/k: navigate • /: filter • e: edit • n: new key • r: reset to default • u: undo • a: apply • esc: back")
+
+	content := lipgloss.JoinVertical(lipgloss.Left, header, statusBar, v.table.View(), help)
+	if v.mode == configConfirm {
+		return overlay.Composite(v.confirmView(), content, overlay.Center, overlay.Center, 0, 0)
+	}
+	return content
+}
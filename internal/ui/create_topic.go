@@ -1,19 +1,42 @@
 package ui
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
+var createTopicLabels = []string{
+	"Topic Name",
+	"Partitions",
+	"Replication Factor",
+	"Configs",
+}
+
+var createTopicPlaceholders = []string{
+	"Enter topic name",
+	"broker default",
+	"broker default",
+	"cleanup.policy=compact retention.ms=604800000",
+}
+
 type CreateTopicForm struct {
-	textInput textinput.Model
-	width     int
-	height    int
+	inputs  []textinput.Model
+	focused int
+	err     string
+	width   int
+	height  int
 }
 
 type TopicSubmittedMsg struct {
-	TopicName string
+	TopicName         string
+	Partitions        int32 // -1 for the broker default
+	ReplicationFactor int16 // -1 for the broker default
+	Configs           map[string]*string
 }
 
 func (f CreateTopicForm) Init() tea.Cmd { return textinput.Blink }
@@ -24,44 +47,129 @@ func (f CreateTopicForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case " ":
-			return f, nil
+			// Spaces only separate configs; names and numbers can't hold them.
+			if f.focused != len(f.inputs)-1 {
+				return f, nil
+			}
+		case "tab", "shift+tab":
+			f.inputs[f.focused].Blur()
+			if msg.String() == "tab" {
+				f.focused = (f.focused + 1) % len(f.inputs)
+			} else {
+				f.focused = (f.focused - 1 + len(f.inputs)) % len(f.inputs)
+			}
+			return f, f.inputs[f.focused].Focus()
 		case "enter":
-			if f.textInput.Value() != "" {
-				return f, func() tea.Msg {
-					return TopicSubmittedMsg{TopicName: f.textInput.Value()}
-				}
+			if f.inputs[0].Value() == "" {
+				return f, nil
+			}
+			submitted, err := f.values()
+			if err != nil {
+				f.err = err.Error()
+				return f, nil
 			}
+			f.err = ""
+			return f, func() tea.Msg { return submitted }
 		case "esc":
 			return f, nil
 		}
 	}
 
-	f.textInput, cmd = f.textInput.Update(msg)
+	f.inputs[f.focused], cmd = f.inputs[f.focused].Update(msg)
 	return f, cmd
 }
+
+func (f CreateTopicForm) values() (TopicSubmittedMsg, error) {
+	submitted := TopicSubmittedMsg{
+		TopicName:         strings.TrimSpace(f.inputs[0].Value()),
+		Partitions:        -1,
+		ReplicationFactor: -1,
+	}
+
+	if v := f.inputs[1].Value(); v != "" {
+		partitions, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || partitions < 1 {
+			return submitted, fmt.Errorf("partitions must be a positive number, got %q", v)
+		}
+		submitted.Partitions = int32(partitions)
+	}
+
+	if v := f.inputs[2].Value(); v != "" {
+		rf, err := strconv.ParseInt(v, 10, 16)
+		if err != nil || rf < 1 {
+			return submitted, fmt.Errorf("replication factor must be a positive number, got %q", v)
+		}
+		submitted.ReplicationFactor = int16(rf)
+	}
+
+	configs, err := ParseConfigs(f.inputs[3].Value())
+	if err != nil {
+		return submitted, err
+	}
+	submitted.Configs = configs
+
+	return submitted, nil
+}
+
+// ParseConfigs parses space separated key=value pairs. Values may contain
+// commas, e.g. cleanup.policy=compact,delete.
+func ParseConfigs(s string) (map[string]*string, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	configs := make(map[string]*string, len(fields))
+	for _, field := range fields {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("config %q must be in key=value form", field)
+		}
+		configs[key] = &value
+	}
+	return configs, nil
+}
+
+// SetError shows an error returned by the broker below the inputs, keeping
+// what was typed so it can be corrected.
+func (f *CreateTopicForm) SetError(err error) {
+	f.err = err.Error()
+}
+
 func (f CreateTopicForm) View() string {
 	title := FormTitleStyle.Render("Create New Topic")
-	input := f.textInput.View()
-	help := FormHelpStyle.Render("enter: create • esc: cancel")
+
+	parts := []string{title}
+	for i, input := range f.inputs {
+		parts = append(parts, createTopicLabels[i]+":", input.View())
+	}
+	if f.err != "" {
+		parts = append(parts, "", FormErrorStyle.Render("✗ "+f.err))
+	}
+	parts = append(parts, FormHelpStyle.Render("enter: create • tab: next field • esc: cancel"))
 
 	content := lipgloss.JoinVertical(
 		lipgloss.Left,
-		title,
-		"Topic Name:",
-		input,
-		help,
+		parts...,
 	)
 
 	return FormBoxStyle.Render(content)
 }
 
 func NewCreateTopicForm() CreateTopicForm {
-	topicNameInput := textinput.New()
-	topicNameInput.Placeholder = "Enter topic name"
-	topicNameInput.Focus()
-	topicNameInput.CharLimit = 100
-	topicNameInput.Width = 50
+	inputs := make([]textinput.Model, len(createTopicLabels))
+	for i := range inputs {
+		input := textinput.New()
+		input.Placeholder = createTopicPlaceholders[i]
+		input.Width = 44
+		inputs[i] = input
+	}
+	inputs[0].CharLimit = 249
+	inputs[1].CharLimit = 10
+	inputs[2].CharLimit = 5
+	inputs[0].Focus()
+
 	return CreateTopicForm{
-		textInput: topicNameInput,
+		inputs: inputs,
 	}
 }
@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxListedDeletes caps how many items a delete confirmation spells out.
+const maxListedDeletes = 10
+
+type DeleteGroupsForm struct {
+	groups []string
+}
+
+type GroupsDeleteMsg struct {
+	Confirmed bool
+}
+
+func NewDeleteGroupsForm(groups []string) DeleteGroupsForm {
+	return DeleteGroupsForm{groups: groups}
+}
+
+func (f DeleteGroupsForm) Init() tea.Cmd { return nil }
+
+func (f DeleteGroupsForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "y", "Y":
+			return f, func() tea.Msg { return GroupsDeleteMsg{Confirmed: true} }
+		case "n", "N", "esc":
+			return f, func() tea.Msg { return GroupsDeleteMsg{Confirmed: false} }
+		}
+	}
+	return f, nil
+}
+
+func (f DeleteGroupsForm) View() string {
+	noun := "group"
+	if len(f.groups) != 1 {
+		noun = "groups"
+	}
+	return renderDeleteConfirm(
+		FormBoxStyle,
+		"Delete Consumer Groups",
+		fmt.Sprintf("Are you sure you want to delete %d %s:", len(f.groups), noun),
+		f.groups,
+	)
+}
+
+type DeleteOffsetsForm struct {
+	group      string
+	partitions map[string][]int32
+}
+
+type OffsetsDeleteMsg struct {
+	Confirmed bool
+}
+
+func NewDeleteOffsetsForm(group string, partitions map[string][]int32) DeleteOffsetsForm {
+	return DeleteOffsetsForm{group: group, partitions: partitions}
+}
+
+func (f DeleteOffsetsForm) Init() tea.Cmd { return nil }
+
+func (f DeleteOffsetsForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "y", "Y":
+			return f, func() tea.Msg { return OffsetsDeleteMsg{Confirmed: true} }
+		case "n", "N", "esc":
+			return f, func() tea.Msg { return OffsetsDeleteMsg{Confirmed: false} }
+		}
+	}
+	return f, nil
+}
+
+func (f DeleteOffsetsForm) View() string {
+	items := make([]string, 0, len(f.partitions))
+	for topic, partitions := range f.partitions {
+		items = append(items, fmt.Sprintf("%s [%s]", topic, formatBrokerIDs(partitions)))
+	}
+	sort.Strings(items)
+
+	return renderDeleteConfirm(
+		FormBoxStyle,
+		"Delete Committed Offsets",
+		fmt.Sprintf("Are you sure you want to delete the offsets %s committed for:", f.group),
+		items,
+	)
+}
+
+func renderDeleteConfirm(box lipgloss.Style, title, question string, items []string) string {
+	listed := items
+	if len(listed) > maxListedDeletes {
+		listed = listed[:maxListedDeletes]
+	}
+	lines := make([]string, 0, len(listed)+1)
+	for _, item := range listed {
+		lines = append(lines, "  "+item)
+	}
+	if more := len(items) - len(listed); more > 0 {
+		lines = append(lines, fmt.Sprintf("  …and %d more", more))
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		FormTitleStyle.Render(title),
+		"",
+		lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("⚠ Warning"),
+		question,
+		strings.Join(lines, "\n"),
+		"",
+		FormHelpStyle.Render("y: confirm • n/esc: cancel"),
+	)
+	return box.Render(content)
+}
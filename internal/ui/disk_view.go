@@ -0,0 +1,345 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type LogDir struct {
+	Broker int32
+	Dir    string
+	Err    error
+}
+
+type LogDirReplica struct {
+	Broker    int32
+	Dir       string
+	Topic     string
+	Partition int32
+	Size      int64
+	// OffsetLag is how far a future replica trails the current one while
+	// it is being moved between dirs, or how far a replica trails the high
+	// watermark otherwise.
+	OffsetLag int64
+	Future    bool
+}
+
+type DiskUsage struct {
+	Dirs     []LogDir
+	Replicas []LogDirReplica
+	Warning  string // set when only some brokers responded
+}
+
+// FormatBytes renders a size with binary units, e.g. 1536 becomes 1.5 KiB.
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+type diskGrouping int
+
+const (
+	diskByBroker diskGrouping = iota
+	diskByDir
+	diskByTopic
+	diskByPartition
+	diskGroupingCount
+)
+
+func (g diskGrouping) String() string {
+	switch g {
+	case diskByBroker:
+		return "broker"
+	case diskByDir:
+		return "log dir"
+	case diskByTopic:
+		return "topic"
+	default:
+		return "partition"
+	}
+}
+
+// diskRow is one aggregated row, kept with its sort keys.
+type diskRow struct {
+	name  string
+	size  int64
+	cells table.Row
+}
+
+type DiskViewModel struct {
+	usage  DiskUsage
+	loaded bool
+	err    error
+
+	grouping   diskGrouping
+	sortByName bool
+
+	table table.Model
+
+	width  int
+	height int
+}
+
+func NewDiskViewModel(width, height int) *DiskViewModel {
+	vm := &DiskViewModel{
+		grouping: diskByTopic,
+		table:    newTable(nil),
+	}
+	vm.resize(width, height)
+	return vm
+}
+
+func (v *DiskViewModel) SetUsage(usage DiskUsage, err error) {
+	v.loaded = true
+	v.err = err
+	if err == nil {
+		v.usage = usage
+	}
+	v.refreshRows()
+}
+
+func (v *DiskViewModel) resize(width, height int) {
+	v.width = width
+	v.height = height
+	v.table.SetHeight(max(height-6, 3))
+	v.refreshRows()
+}
+
+func (v *DiskViewModel) columns() []table.Column {
+	switch v.grouping {
+	case diskByBroker:
+		return fitColumns([]table.Column{
+			{Title: "Broker", Width: 0},
+			{Title: "Dirs", Width: 6},
+			{Title: "Replicas", Width: 9},
+			{Title: "Moving", Width: 7},
+			{Title: "Size", Width: 11},
+		}, v.width, 0)
+	case diskByDir:
+		return fitColumns([]table.Column{
+			{Title: "Broker", Width: 7},
+			{Title: "Dir", Width: 0},
+			{Title: "Replicas", Width: 9},
+			{Title: "Moving", Width: 7},
+			{Title: "Size", Width: 11},
+		}, v.width, 1)
+	case diskByTopic:
+		return fitColumns([]table.Column{
+			{Title: "Topic", Width: 0},
+			{Title: "Partitions", Width: 10},
+			{Title: "Replicas", Width: 9},
+			{Title: "Moving", Width: 7},
+			{Title: "Size", Width: 11},
+		}, v.width, 0)
+	default:
+		return fitColumns([]table.Column{
+			{Title: "Topic", Width: 0},
+			{Title: "Partition", Width: 9},
+			{Title: "Broker", Width: 7},
+			{Title: "Dir", Width: 24},
+			{Title: "Offset Lag", Width: 12},
+			{Title: "Size", Width: 11},
+		}, v.width, 0)
+	}
+}
+
+type diskTotals struct {
+	replicas int
+	moving   int
+	size     int64
+	keys     map[string]bool
+}
+
+func (t *diskTotals) add(r LogDirReplica, key string) {
+	t.replicas++
+	t.size += r.Size
+	if r.Future {
+		t.moving++
+	}
+	if t.keys == nil {
+		t.keys = make(map[string]bool)
+	}
+	t.keys[key] = true
+}
+
+func (v *DiskViewModel) aggregate() []diskRow {
+	var rows []diskRow
+
+	switch v.grouping {
+	case diskByBroker:
+		totals := make(map[int32]*diskTotals)
+		for _, d := range v.usage.Dirs {
+			if totals[d.Broker] == nil {
+				totals[d.Broker] = &diskTotals{keys: make(map[string]bool)}
+			}
+			totals[d.Broker].keys[d.Dir] = true
+		}
+		for _, r := range v.usage.Replicas {
+			totals[r.Broker].add(r, r.Dir)
+		}
+		for broker, t := range totals {
+			name := fmt.Sprintf("%d", broker)
+			rows = append(rows, diskRow{name: fmt.Sprintf("%010d", broker), size: t.size, cells: table.Row{
+				name, fmt.Sprintf("%d", len(t.keys)), fmt.Sprintf("%d", t.replicas), fmt.Sprintf("%d", t.moving), FormatBytes(t.size),
+			}})
+		}
+
+	case diskByDir:
+		type dirKey struct {
+			broker int32
+			dir    string
+		}
+		totals := make(map[dirKey]*diskTotals)
+		errs := make(map[dirKey]error)
+		for _, d := range v.usage.Dirs {
+			totals[dirKey{d.Broker, d.Dir}] = &diskTotals{}
+			errs[dirKey{d.Broker, d.Dir}] = d.Err
+		}
+		for _, r := range v.usage.Replicas {
+			totals[dirKey{r.Broker, r.Dir}].add(r, r.Topic)
+		}
+		for k, t := range totals {
+			dir := k.dir
+			if err := errs[k]; err != nil {
+				dir += " (" + err.Error() + ")"
+			}
+			rows = append(rows, diskRow{name: fmt.Sprintf("%010d %s", k.broker, k.dir), size: t.size, cells: table.Row{
+				fmt.Sprintf("%d", k.broker), dir, fmt.Sprintf("%d", t.replicas), fmt.Sprintf("%d", t.moving), FormatBytes(t.size),
+			}})
+		}
+
+	case diskByTopic:
+		totals := make(map[string]*diskTotals)
+		for _, r := range v.usage.Replicas {
+			if totals[r.Topic] == nil {
+				totals[r.Topic] = &diskTotals{}
+			}
+			totals[r.Topic].add(r, fmt.Sprintf("%d", r.Partition))
+		}
+		for topic, t := range totals {
+			rows = append(rows, diskRow{name: topic, size: t.size, cells: table.Row{
+				topic, fmt.Sprintf("%d", len(t.keys)), fmt.Sprintf("%d", t.replicas), fmt.Sprintf("%d", t.moving), FormatBytes(t.size),
+			}})
+		}
+
+	default:
+		for _, r := range v.usage.Replicas {
+			dir := r.Dir
+			lag := fmt.Sprintf("%d", r.OffsetLag)
+			if r.Future {
+				dir = "→ " + dir
+				lag += " (moving)"
+			}
+			rows = append(rows, diskRow{name: fmt.Sprintf("%s %010d %010d", r.Topic, r.Partition, r.Broker), size: r.Size, cells: table.Row{
+				r.Topic, fmt.Sprintf("%d", r.Partition), fmt.Sprintf("%d", r.Broker), dir, lag, FormatBytes(r.Size),
+			}})
+		}
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if !v.sortByName && rows[i].size != rows[j].size {
+			return rows[i].size > rows[j].size
+		}
+		return rows[i].name < rows[j].name
+	})
+	return rows
+}
+
+func (v *DiskViewModel) refreshRows() {
+	aggregated := v.aggregate()
+	rows := make([]table.Row, len(aggregated))
+	for i, r := range aggregated {
+		rows[i] = r.cells
+	}
+
+	// The groupings have different columns, so clear the rows first.
+	v.table.SetRows(nil)
+	v.table.SetColumns(v.columns())
+	v.table.SetRows(rows)
+	if v.table.Cursor() >= len(rows) {
+		v.table.SetCursor(max(len(rows)-1, 0))
+	}
+}
+
+func (v *DiskViewModel) Init() tea.Cmd {
+	return nil
+}
+
+func (v *DiskViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.resize(msg.Width, msg.Height)
+		return v, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "r":
+			return v, func() tea.Msg { return RefreshMsg{} }
+		case "g":
+			v.grouping = (v.grouping + 1) % diskGroupingCount
+			v.table.SetCursor(0)
+			v.refreshRows()
+			return v, nil
+		case "o":
+			v.sortByName = !v.sortByName
+			v.refreshRows()
+			return v, nil
+		}
+	}
+
+	v.table, cmd = v.table.Update(msg)
+	return v, cmd
+}
+
+func (v *DiskViewModel) View() string {
+	subtle := lipgloss.NewStyle().Foreground(SubtleColor)
+
+	var statusBar string
+	switch {
+	case !v.loaded:
+		statusBar = subtle.Render("⏳ Loading log dirs...")
+	case v.err != nil:
+		statusBar = partitionErrorStyle.Render(fmt.Sprintf("Failed to describe log dirs: %v", v.err))
+	default:
+		var total int64
+		var moving int
+		brokers := make(map[int32]bool)
+		for _, d := range v.usage.Dirs {
+			brokers[d.Broker] = true
+		}
+		for _, r := range v.usage.Replicas {
+			total += r.Size
+			if r.Future {
+				moving++
+			}
+		}
+		sortBy := "size"
+		if v.sortByName {
+			sortBy = "name"
+		}
+		statusBar = subtle.Render(fmt.Sprintf("%s across %d brokers, %d log dirs • by %s • sorted by %s",
+			FormatBytes(total), len(brokers), len(v.usage.Dirs), v.grouping, sortBy))
+		if moving > 0 {
+			statusBar += partitionWarnStyle.Render(fmt.Sprintf(" • %d replicas moving", moving))
+		}
+		if v.usage.Warning != "" {
+			statusBar += partitionWarnStyle.Render(" • incomplete: " + v.usage.Warning)
+		}
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, statusBar, v.table.View())
+}
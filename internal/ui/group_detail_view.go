@@ -0,0 +1,274 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type GroupMember struct {
+	MemberID   string
+	InstanceID string
+	ClientID   string
+	ClientHost string
+	Assigned   map[string][]int32
+}
+
+type GroupPartitionLag struct {
+	Topic     string
+	Partition int32
+	ClientID  string // empty when no member owns the partition
+	Committed int64  // -1 when the group has no commit for the partition
+	End       int64
+	Lag       int64 // -1 when it could not be calculated
+	Err       error
+}
+
+type GroupDetail struct {
+	GroupSummary
+	Members    []GroupMember
+	Partitions []GroupPartitionLag
+}
+
+// ResetOffsetsRequestedMsg asks the parent to open the reset offsets view
+// for the group's partitions.
+type ResetOffsetsRequestedMsg struct {
+	Group      string
+	Members    int
+	Partitions []GroupPartitionLag
+}
+
+type OffsetsDeleteRequestedMsg struct {
+	Group      string
+	Partitions map[string][]int32
+}
+
+type topicPartition struct {
+	topic     string
+	partition int32
+}
+
+type GroupDetailViewModel struct {
+	name   string
+	detail GroupDetail
+	marked map[topicPartition]bool
+	loaded bool
+	err    error
+
+	table table.Model
+
+	width  int
+	height int
+}
+
+func NewGroupDetailViewModel(name string, width, height int) *GroupDetailViewModel {
+	vm := &GroupDetailViewModel{
+		name:   name,
+		marked: make(map[topicPartition]bool),
+		table:  newTable(nil),
+	}
+	vm.resize(width, height)
+	return vm
+}
+
+func (v *GroupDetailViewModel) SetDetail(detail GroupDetail, err error) {
+	v.loaded = true
+	v.err = err
+	if err == nil {
+		v.detail = detail
+		exists := make(map[topicPartition]bool, len(detail.Partitions))
+		for _, p := range detail.Partitions {
+			exists[topicPartition{p.Topic, p.Partition}] = true
+		}
+		for tp := range v.marked {
+			if !exists[tp] {
+				delete(v.marked, tp)
+			}
+		}
+	}
+	v.refreshRows()
+	v.resize(v.width, v.height)
+}
+
+func (v *GroupDetailViewModel) resize(width, height int) {
+	v.width = width
+	v.height = height
+	v.table.SetColumns(fitColumns([]table.Column{
+		{Title: "", Width: 1},
+		{Title: "Topic", Width: 0},
+		{Title: "Partition", Width: 9},
+		{Title: "Consumer", Width: 24},
+		{Title: "Committed", Width: 14},
+		{Title: "End", Width: 14},
+		{Title: "Lag", Width: 12},
+	}, width, 1))
+	v.table.SetHeight(max(height-10-len(v.detail.Members), 3))
+}
+
+func (v *GroupDetailViewModel) refreshRows() {
+	rows := make([]table.Row, 0, len(v.detail.Partitions))
+	for _, p := range v.detail.Partitions {
+		consumer := p.ClientID
+		if consumer == "" {
+			consumer = "-"
+		}
+		committed := "-"
+		if p.Committed >= 0 {
+			committed = fmt.Sprintf("%d", p.Committed)
+		}
+		lag := fmt.Sprintf("%d", p.Lag)
+		if p.Lag < 0 {
+			lag = "?"
+		}
+		marker := ""
+		if v.marked[topicPartition{p.Topic, p.Partition}] {
+			marker = "✓"
+		}
+		rows = append(rows, table.Row{
+			marker,
+			p.Topic,
+			fmt.Sprintf("%d", p.Partition),
+			consumer,
+			committed,
+			fmt.Sprintf("%d", p.End),
+			lag,
+		})
+	}
+	v.table.SetRows(rows)
+}
+
+// markedPartitions returns the marked partitions, or the one under the
+// cursor when none are marked.
+func (v *GroupDetailViewModel) markedPartitions() map[string][]int32 {
+	partitions := make(map[string][]int32)
+	for _, p := range v.detail.Partitions {
+		if v.marked[topicPartition{p.Topic, p.Partition}] {
+			partitions[p.Topic] = append(partitions[p.Topic], p.Partition)
+		}
+	}
+	if len(partitions) == 0 {
+		if cursor := v.table.Cursor(); cursor >= 0 && cursor < len(v.detail.Partitions) {
+			p := v.detail.Partitions[cursor]
+			partitions[p.Topic] = []int32{p.Partition}
+		}
+	}
+	return partitions
+}
+
+func (v *GroupDetailViewModel) Init() tea.Cmd {
+	return nil
+}
+
+func (v *GroupDetailViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.resize(msg.Width, msg.Height)
+		return v, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			return v, func() tea.Msg { return BackMsg{} }
+		case "r":
+			return v, func() tea.Msg { return RefreshMsg{} }
+		case "R":
+			if !v.loaded || v.err != nil {
+				return v, nil
+			}
+			msg := ResetOffsetsRequestedMsg{Group: v.name, Members: len(v.detail.Members), Partitions: v.detail.Partitions}
+			return v, func() tea.Msg { return msg }
+		case " ":
+			if cursor := v.table.Cursor(); cursor >= 0 && cursor < len(v.detail.Partitions) {
+				p := v.detail.Partitions[cursor]
+				tp := topicPartition{p.Topic, p.Partition}
+				if v.marked[tp] {
+					delete(v.marked, tp)
+				} else {
+					v.marked[tp] = true
+				}
+				v.refreshRows()
+			}
+			return v, nil
+		case "x":
+			partitions := v.markedPartitions()
+			if len(partitions) == 0 {
+				return v, nil
+			}
+			msg := OffsetsDeleteRequestedMsg{Group: v.name, Partitions: partitions}
+			return v, func() tea.Msg { return msg }
+		}
+	}
+
+	v.table, cmd = v.table.Update(msg)
+	return v, cmd
+}
+
+func formatAssignment(assigned map[string][]int32) string {
+	if len(assigned) == 0 {
+		return "no partitions"
+	}
+	topics := make([]string, 0, len(assigned))
+	for topic, partitions := range assigned {
+		topics = append(topics, fmt.Sprintf("%s[%s]", topic, formatBrokerIDs(partitions)))
+	}
+	sort.Strings(topics)
+	return strings.Join(topics, " ")
+}
+
+func (v *GroupDetailViewModel) renderMembers() string {
+	if len(v.detail.Members) == 0 {
+		return emptyStateStyle.Padding(0).Render("No active members")
+	}
+
+	lines := make([]string, 0, len(v.detail.Members))
+	for _, m := range v.detail.Members {
+		id := m.MemberID
+		if m.InstanceID != "" {
+			id = m.InstanceID
+		}
+		lines = append(lines, fmt.Sprintf("%s %s %s",
+			messageHeaderStyle.Render(m.ClientID),
+			messageLabelStyle.UnsetWidth().Render(fmt.Sprintf("%s (%s)", id, m.ClientHost)),
+			messageValueStyle.Render(formatAssignment(m.Assigned)),
+		))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (v *GroupDetailViewModel) View() string {
+	headerText := fmt.Sprintf("👥 %s", v.name)
+	if v.loaded && v.err == nil {
+		d := v.detail
+		headerText += fmt.Sprintf(" • %s • %d members • lag %d", d.State, len(d.Members), d.Lag)
+		if d.Protocol != "" {
+			headerText += fmt.Sprintf(" • %s/%s", d.ProtocolType, d.Protocol)
+		}
+	}
+	header := HeaderStyle.Width(v.width).Render(headerText)
+
+	var body string
+	switch {
+	case !v.loaded:
+		body = emptyStateStyle.Render("⏳ Loading group...")
+	case v.err != nil:
+		body = partitionErrorStyle.Render(fmt.Sprintf("Failed to describe group: %v", v.err))
+	default:
+		body = lipgloss.JoinVertical(lipgloss.Left,
+			partitionHeaderStyle.Render("Members"),
+			v.renderMembers(),
+			"",
+			partitionHeaderStyle.Render("Partitions"),
+			v.table.View(),
+		)
+	}
+
+	help := HelpStyle.Render("↑/↓ j/k: navigate • space: mark • x: delete offsets • r: refresh • R: reset offsets • esc: back")
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, body, help)
+}
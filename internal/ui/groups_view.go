@@ -0,0 +1,237 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type GroupSummary struct {
+	Name         string
+	State        string
+	ProtocolType string
+	Protocol     string
+	Members      int
+	Lag          int64
+	Err          error
+}
+
+type GroupSelectedMsg struct {
+	Name string
+}
+
+type GroupsDeleteRequestedMsg struct {
+	Groups []string
+}
+
+type GroupsViewModel struct {
+	groups  []GroupSummary
+	visible []GroupSummary
+	marked  map[string]bool
+	loaded  bool
+	err     error
+
+	table       table.Model
+	filtering   bool
+	filterInput textinput.Model
+
+	width  int
+	height int
+}
+
+func NewGroupsViewModel(width, height int) *GroupsViewModel {
+	filterInput := textinput.New()
+	filterInput.Placeholder = "Filter groups..."
+	filterInput.Width = 40
+
+	vm := &GroupsViewModel{
+		table:       newTable(nil),
+		filterInput: filterInput,
+		marked:      make(map[string]bool),
+	}
+	vm.resize(width, height)
+	return vm
+}
+
+func (v *GroupsViewModel) SetGroups(groups []GroupSummary, err error) {
+	v.loaded = true
+	v.err = err
+	if err == nil {
+		v.groups = groups
+		exists := make(map[string]bool, len(groups))
+		for _, g := range groups {
+			exists[g.Name] = true
+		}
+		for name := range v.marked {
+			if !exists[name] {
+				delete(v.marked, name)
+			}
+		}
+	}
+	v.refreshRows()
+}
+
+// markedGroups returns the marked groups, or the one under the cursor when none
+// are marked.
+func (v *GroupsViewModel) markedGroups() []string {
+	if len(v.marked) == 0 {
+		if g, ok := v.selected(); ok {
+			return []string{g.Name}
+		}
+		return nil
+	}
+	names := make([]string, 0, len(v.marked))
+	for name := range v.marked {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Typing reports whether keys are going to a text input, so the parent
+// should not treat them as shortcuts.
+func (v *GroupsViewModel) Typing() bool {
+	return v.filtering
+}
+
+func (v *GroupsViewModel) resize(width, height int) {
+	v.width = width
+	v.height = height
+	v.table.SetColumns(fitColumns([]table.Column{
+		{Title: "", Width: 1},
+		{Title: "Group", Width: 0},
+		{Title: "State", Width: 20},
+		{Title: "Protocol", Width: 18},
+		{Title: "Members", Width: 8},
+		{Title: "Lag", Width: 12},
+	}, width, 1))
+	v.table.SetHeight(max(height-6, 3))
+}
+
+func (v *GroupsViewModel) refreshRows() {
+	filter := strings.ToLower(v.filterInput.Value())
+
+	v.visible = v.visible[:0]
+	rows := make([]table.Row, 0, len(v.groups))
+	for _, g := range v.groups {
+		if filter != "" && !strings.Contains(strings.ToLower(g.Name), filter) {
+			continue
+		}
+		v.visible = append(v.visible, g)
+
+		protocol := g.ProtocolType
+		if g.Protocol != "" {
+			protocol += "/" + g.Protocol
+		}
+		lag := fmt.Sprintf("%d", g.Lag)
+		if g.Err != nil {
+			lag = "error"
+		}
+		marker := ""
+		if v.marked[g.Name] {
+			marker = "✓"
+		}
+		rows = append(rows, table.Row{marker, g.Name, g.State, protocol, fmt.Sprintf("%d", g.Members), lag})
+	}
+	v.table.SetRows(rows)
+	if v.table.Cursor() >= len(rows) {
+		v.table.SetCursor(max(len(rows)-1, 0))
+	}
+}
+
+func (v *GroupsViewModel) selected() (GroupSummary, bool) {
+	cursor := v.table.Cursor()
+	if cursor < 0 || cursor >= len(v.visible) {
+		return GroupSummary{}, false
+	}
+	return v.visible[cursor], true
+}
+
+func (v *GroupsViewModel) Init() tea.Cmd {
+	return nil
+}
+
+func (v *GroupsViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.resize(msg.Width, msg.Height)
+		return v, nil
+
+	case tea.KeyMsg:
+		if v.filtering {
+			switch msg.String() {
+			case "enter", "esc":
+				if msg.String() == "esc" {
+					v.filterInput.SetValue("")
+				}
+				v.filtering = false
+				v.filterInput.Blur()
+				v.refreshRows()
+				return v, nil
+			}
+			v.filterInput, cmd = v.filterInput.Update(msg)
+			v.refreshRows()
+			return v, cmd
+		}
+
+		switch msg.String() {
+		case "/":
+			v.filtering = true
+			return v, v.filterInput.Focus()
+		case "r":
+			return v, func() tea.Msg { return RefreshMsg{} }
+		case " ":
+			if g, ok := v.selected(); ok {
+				if v.marked[g.Name] {
+					delete(v.marked, g.Name)
+				} else {
+					v.marked[g.Name] = true
+				}
+				v.refreshRows()
+			}
+			return v, nil
+		case "x":
+			groups := v.markedGroups()
+			if len(groups) == 0 {
+				return v, nil
+			}
+			return v, func() tea.Msg { return GroupsDeleteRequestedMsg{Groups: groups} }
+		case "enter":
+			if g, ok := v.selected(); ok {
+				return v, func() tea.Msg { return GroupSelectedMsg{Name: g.Name} }
+			}
+			return v, nil
+		}
+	}
+
+	v.table, cmd = v.table.Update(msg)
+	return v, cmd
+}
+
+func (v *GroupsViewModel) View() string {
+	var statusBar string
+	switch {
+	case v.filtering:
+		statusBar = lipgloss.NewStyle().Foreground(AccentColor).Render("Filter: ") + v.filterInput.View()
+	case !v.loaded:
+		statusBar = lipgloss.NewStyle().Foreground(SubtleColor).Render("⏳ Loading consumer groups...")
+	case v.err != nil:
+		statusBar = partitionErrorStyle.Render(fmt.Sprintf("Failed to load consumer groups: %v", v.err))
+	case v.filterInput.Value() != "":
+		statusBar = lipgloss.NewStyle().Foreground(SubtleColor).Render(
+			fmt.Sprintf("🔍 Filter: '%s' • %d of %d groups", v.filterInput.Value(), len(v.visible), len(v.groups)))
+	case len(v.marked) > 0:
+		statusBar = lipgloss.NewStyle().Foreground(SubtleColor).Render(fmt.Sprintf("%d groups • %d marked", len(v.groups), len(v.marked)))
+	default:
+		statusBar = lipgloss.NewStyle().Foreground(SubtleColor).Render(fmt.Sprintf("%d groups", len(v.groups)))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, statusBar, v.table.View())
+}
@@ -0,0 +1,229 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+var (
+	detailLabelStyle = lipgloss.NewStyle().
+				Foreground(SubtleColor).
+				Width(16)
+
+	detailSectionStyle = lipgloss.NewStyle().
+				Foreground(AccentColor).
+				Bold(true).
+				MarginTop(1)
+
+	emptyValueStyle = lipgloss.NewStyle().Foreground(SubtleColor).Italic(true)
+
+	jsonKeyStyle     = lipgloss.NewStyle().Foreground(AccentColor)
+	jsonStringStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("114"))
+	jsonNumberStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("215"))
+	jsonLiteralStyle = lipgloss.NewStyle().Foreground(PrimaryColor)
+)
+
+var compressionNames = []string{"none", "gzip", "snappy", "lz4", "zstd"}
+
+// messageDetail shows one record in full. It scrolls on its own, leaving
+// the list's position alone.
+type messageDetail struct {
+	record   *kgo.Record
+	decoded  decodedRecord
+	viewport viewport.Model
+	width    int
+}
+
+func newMessageDetail(record *kgo.Record, decoded decodedRecord, width, height int) *messageDetail {
+	d := &messageDetail{record: record, decoded: decoded, viewport: viewport.New(width, 0)}
+	d.resize(width, height)
+	return d
+}
+
+func (d *messageDetail) resize(width, height int) {
+	d.width = width
+	d.viewport.Width = width
+	d.viewport.Height = max(height-6, 3)
+	d.viewport.SetContent(renderRecordDetail(d.record, d.decoded, width-2))
+}
+
+func (d *messageDetail) Update(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "g":
+			d.viewport.GotoTop()
+			return nil
+		case "G":
+			d.viewport.GotoBottom()
+			return nil
+		}
+	}
+	d.viewport, cmd = d.viewport.Update(msg)
+	return cmd
+}
+
+func (d *messageDetail) View(topic string) string {
+	header := HeaderStyle.Width(d.width).Render(fmt.Sprintf("📨 %s • partition %d • offset %d",
+		topic, d.record.Partition, d.record.Offset))
+
+	help := HelpStyle.Render(fmt.Sprintf(
+		"↑/↓ j/k: scroll • pgup/pgdn: page • g/G: top/bottom • %3.f%% • esc: back to messages",
+		d.viewport.ScrollPercent()*100))
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, d.viewport.View(), help)
+}
+
+func recordSize(r *kgo.Record) int {
+	size := len(r.Key) + len(r.Value)
+	for _, h := range r.Headers {
+		size += len(h.Key) + len(h.Value)
+	}
+	return size
+}
+
+func renderRecordDetail(r *kgo.Record, decoded decodedRecord, width int) string {
+	field := func(label, value string) string {
+		return detailLabelStyle.Render(label) + messageValueStyle.Render(value)
+	}
+
+	timestampType := "CreateTime"
+	switch r.Attrs.TimestampType() {
+	case 1:
+		timestampType = "LogAppendTime"
+	case -1:
+		timestampType = "none"
+	}
+
+	producer := "none"
+	if r.ProducerID >= 0 {
+		producer = fmt.Sprintf("id %d, epoch %d", r.ProducerID, r.ProducerEpoch)
+	}
+	if r.Attrs.IsTransactional() {
+		producer += " • transactional"
+	}
+
+	compression := "unknown"
+	if c := int(r.Attrs.CompressionType()); c < len(compressionNames) {
+		compression = compressionNames[c]
+	}
+
+	lines := []string{
+		field("Partition", fmt.Sprintf("%d", r.Partition)),
+		field("Offset", fmt.Sprintf("%d", r.Offset)),
+		field("Timestamp", r.Timestamp.Format("2006-01-02 15:04:05.000 MST")+" ("+timestampType+")"),
+		field("Leader epoch", fmt.Sprintf("%d", r.LeaderEpoch)),
+		field("Producer", producer),
+		field("Compression", compression),
+		field("Size", fmt.Sprintf("%s (key %s, value %s)",
+			FormatBytes(int64(recordSize(r))), FormatBytes(int64(len(r.Key))), FormatBytes(int64(len(r.Value))))),
+	}
+
+	lines = append(lines, detailSectionStyle.Render(fmt.Sprintf("Headers (%d)", len(r.Headers))))
+	if len(r.Headers) == 0 {
+		lines = append(lines, emptyValueStyle.Render("none"))
+	}
+	for _, h := range r.Headers {
+		header := jsonKeyStyle.Render(h.Key) + ": " + messageValueStyle.Render(formatHeaderValue(h.Value))
+		lines = append(lines, lipgloss.NewStyle().Width(width).Render(header))
+	}
+
+	payload := func(raw []byte, text string, err error) string {
+		if decoded.pending && len(raw) > 0 {
+			return emptyValueStyle.Render("decoding…")
+		}
+		return formatPayload(raw, text, err, width)
+	}
+	lines = append(lines,
+		detailSectionStyle.Render("Key • "+decoded.keyFormat),
+		payload(r.Key, decoded.key, decoded.keyErr),
+		detailSectionStyle.Render("Value • "+decoded.valueFormat),
+		payload(r.Value, decoded.value, decoded.valueErr),
+	)
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// formatPayload renders a decoded key or value in full, with JSON objects
+// and arrays pretty-printed and highlighted and other text wrapped to width.
+func formatPayload(raw []byte, text string, err error, width int) string {
+	switch {
+	case raw == nil:
+		return emptyValueStyle.Render("(null)")
+	case len(raw) == 0:
+		return emptyValueStyle.Render("(empty)")
+	case err != nil:
+		return partitionErrorStyle.Width(width).Render(err.Error())
+	}
+
+	if trimmed := strings.TrimSpace(text); len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, []byte(trimmed), "", "  "); err == nil {
+			return lipgloss.NewStyle().Width(width).Render(highlightJSON(indented.String()))
+		}
+	}
+	return lipgloss.NewStyle().Width(width).Render(messageValueStyle.Render(text))
+}
+
+// formatHeaderValue shows a header value as text when it is printable
+// UTF-8, and in hex otherwise, so binary values can't garble the screen.
+func formatHeaderValue(b []byte) string {
+	if utf8.Valid(b) && strings.IndexFunc(string(b), func(r rune) bool { return !unicode.IsPrint(r) }) < 0 {
+		return string(b)
+	}
+	return "0x" + hex.EncodeToString(b)
+}
+
+// highlightJSON colours the tokens of already valid, indented JSON. Object
+// keys are told apart from string values by the colon that follows them.
+func highlightJSON(s string) string {
+	var out strings.Builder
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '"':
+			j := i + 1
+			for j < len(s) && s[j] != '"' {
+				if s[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			j = min(j+1, len(s))
+			style := jsonStringStyle
+			if k := strings.IndexFunc(s[j:], func(r rune) bool { return r != ' ' }); k >= 0 && s[j+k] == ':' {
+				style = jsonKeyStyle
+			}
+			out.WriteString(style.Render(s[i:j]))
+			i = j
+		case c == '-' || c >= '0' && c <= '9':
+			j := i + 1
+			for j < len(s) && strings.IndexByte("0123456789.eE+-", s[j]) >= 0 {
+				j++
+			}
+			out.WriteString(jsonNumberStyle.Render(s[i:j]))
+			i = j
+		case c == 't' || c == 'f' || c == 'n':
+			j := i + 1
+			for j < len(s) && s[j] >= 'a' && s[j] <= 'z' {
+				j++
+			}
+			out.WriteString(jsonLiteralStyle.Render(s[i:j]))
+			i = j
+		default:
+			out.WriteByte(c)
+			i++
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,205 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type PartitionInfo struct {
+	Partition        int32
+	Leader           int32 // -1 when the partition has no leader
+	Replicas         []int32
+	ISR              []int32
+	OfflineReplicas  []int32
+	LogStartOffset   int64
+	HighWatermark    int64
+	LastStableOffset int64
+	Err              error
+}
+
+func (p PartitionInfo) Leaderless() bool {
+	return p.Leader < 0
+}
+
+func (p PartitionInfo) UnderReplicated() bool {
+	return len(p.ISR) < len(p.Replicas)
+}
+
+type RefreshMsg struct{}
+
+var (
+	partitionHeaderStyle = lipgloss.NewStyle().
+				Foreground(AccentColor).
+				Bold(true)
+
+	partitionWarnStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("214"))
+
+	partitionErrorStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("196")).
+				Bold(true)
+)
+
+type PartitionsViewModel struct {
+	topicName  string
+	partitions []PartitionInfo
+	loaded     bool
+	err        error
+	viewport   viewport.Model
+	width      int
+	height     int
+}
+
+func NewPartitionsViewModel(topicName string, width, height int) *PartitionsViewModel {
+	return &PartitionsViewModel{
+		topicName: topicName,
+		viewport:  viewport.New(width, height-6),
+		width:     width,
+		height:    height,
+	}
+}
+
+func (v *PartitionsViewModel) SetPartitions(partitions []PartitionInfo, err error) {
+	v.partitions = partitions
+	v.err = err
+	v.loaded = true
+}
+
+func (v *PartitionsViewModel) Init() tea.Cmd {
+	return nil
+}
+
+func (v *PartitionsViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.viewport.Width = msg.Width
+		v.viewport.Height = msg.Height - 6
+		v.width = msg.Width
+		v.height = msg.Height
+		return v, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			return v, func() tea.Msg { return BackMsg{} }
+		case "r":
+			return v, func() tea.Msg { return RefreshMsg{} }
+		case "g":
+			v.viewport.GotoTop()
+			return v, nil
+		case "G":
+			v.viewport.GotoBottom()
+			return v, nil
+		}
+	}
+
+	v.viewport, cmd = v.viewport.Update(msg)
+	return v, cmd
+}
+
+func formatBrokerIDs(ids []int32) string {
+	if len(ids) == 0 {
+		return "-"
+	}
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = fmt.Sprintf("%d", id)
+	}
+	return strings.Join(parts, ",")
+}
+
+const partitionRowFormat = "%-9s %-7s %-14s %-14s %-9s %14s %14s %14s %12s  %s"
+
+func (v *PartitionsViewModel) renderPartitions() string {
+	if !v.loaded {
+		return emptyStateStyle.Render("⏳ Loading partitions...")
+	}
+	if v.err != nil {
+		return partitionErrorStyle.Render(fmt.Sprintf("Failed to load partitions: %v", v.err))
+	}
+
+	var content strings.Builder
+	content.WriteString(partitionHeaderStyle.Render(fmt.Sprintf(partitionRowFormat,
+		"Partition", "Leader", "Replicas", "ISR", "Offline", "Log Start", "High Watermark", "Last Stable", "Messages", "")))
+	content.WriteString("\n")
+
+	for _, p := range v.partitions {
+		if p.Err != nil {
+			content.WriteString(partitionErrorStyle.Render(fmt.Sprintf("%-9d %v", p.Partition, p.Err)))
+			content.WriteString("\n")
+			continue
+		}
+
+		leader := fmt.Sprintf("%d", p.Leader)
+		status := ""
+		style := messageValueStyle
+		switch {
+		case p.Leaderless():
+			leader = "none"
+			status = "no leader"
+			style = partitionErrorStyle
+		case len(p.OfflineReplicas) > 0:
+			status = "offline replicas"
+			style = partitionErrorStyle
+		case p.UnderReplicated():
+			status = "under-replicated"
+			style = partitionWarnStyle
+		}
+
+		row := fmt.Sprintf(partitionRowFormat,
+			fmt.Sprintf("%d", p.Partition),
+			leader,
+			formatBrokerIDs(p.Replicas),
+			formatBrokerIDs(p.ISR),
+			formatBrokerIDs(p.OfflineReplicas),
+			fmt.Sprintf("%d", p.LogStartOffset),
+			fmt.Sprintf("%d", p.HighWatermark),
+			fmt.Sprintf("%d", p.LastStableOffset),
+			fmt.Sprintf("%d", p.HighWatermark-p.LogStartOffset),
+			status,
+		)
+		content.WriteString(style.Render(row))
+		content.WriteString("\n")
+	}
+
+	return content.String()
+}
+
+func (v *PartitionsViewModel) View() string {
+	v.viewport.SetContent(v.renderPartitions())
+
+	headerText := fmt.Sprintf("▦ %s partitions", v.topicName)
+	if v.loaded && v.err == nil {
+		var underReplicated, leaderless int
+		for _, p := range v.partitions {
+			if p.Leaderless() {
+				leaderless++
+			} else if p.UnderReplicated() {
+				underReplicated++
+			}
+		}
+		headerText += fmt.Sprintf(" • %d partitions", len(v.partitions))
+		if underReplicated > 0 {
+			headerText += fmt.Sprintf(" • %d under-replicated", underReplicated)
+		}
+		if leaderless > 0 {
+			headerText += fmt.Sprintf(" • %d without leader", leaderless)
+		}
+	}
+	header := HeaderStyle.Width(v.width).Render(headerText)
+
+	help := HelpStyle.Render("↑/↓ j/k: scroll • g/G: top/bottom • r: refresh • esc: back")
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		header,
+		v.viewport.View(),
+		help,
+	)
+}
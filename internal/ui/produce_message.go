@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"slices"
+
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -33,9 +35,22 @@ type ProduceMessageValues struct {
 	Headers         string
 }
 
+// formInput is a text input, or for the serde fields a choice between
+// the serializers that cycles with ←/→.
 type formInput struct {
-	field string
-	input textinput.Model
+	field   string
+	input   textinput.Model
+	choices []string
+	choice  int
+}
+
+func (fi formInput) picker() bool { return fi.choices != nil }
+
+func (fi formInput) value() string {
+	if fi.picker() {
+		return fi.choices[fi.choice]
+	}
+	return fi.input.Value()
 }
 
 type ProduceMessageForm struct {
@@ -44,6 +59,7 @@ type ProduceMessageForm struct {
 	topicName string
 	focused   int
 	inputs    []formInput
+	err       string
 }
 
 type ProduceMsg struct {
@@ -56,7 +72,9 @@ type ProduceMsg struct {
 	Headers         string
 }
 
-func NewProduceMessageForm(topicName string) ProduceMessageForm {
+// NewProduceMessageForm starts the serde pickers on keySerde and
+// valueSerde, or on string when they aren't among serializers.
+func NewProduceMessageForm(topicName, keySerde, valueSerde string, serializers []string) ProduceMessageForm {
 
 	inputs := make([]formInput, len(fields))
 	for i := range fields {
@@ -70,6 +88,12 @@ func NewProduceMessageForm(topicName string) ProduceMessageForm {
 			field: fields[i],
 			input: ti,
 		}
+		switch fields[i] {
+		case "KeySerde":
+			inputs[i].choices, inputs[i].choice = serializers, serializerIndex(serializers, keySerde)
+		case "ValueSerde":
+			inputs[i].choices, inputs[i].choice = serializers, serializerIndex(serializers, valueSerde)
+		}
 	}
 
 	inputs[0].input.Focus()
@@ -83,6 +107,19 @@ func NewProduceMessageForm(topicName string) ProduceMessageForm {
 	}
 }
 
+func serializerIndex(serializers []string, name string) int {
+	if i := slices.Index(serializers, name); i >= 0 {
+		return i
+	}
+	return max(slices.Index(serializers, "string"), 0)
+}
+
+// SetError shows why the record couldn't be built below the inputs,
+// keeping what was typed so it can be corrected.
+func (f *ProduceMessageForm) SetError(err error) {
+	f.err = err.Error()
+}
+
 func (f ProduceMessageForm) Init() tea.Cmd { return textinput.Blink }
 func (f ProduceMessageForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
@@ -100,23 +137,32 @@ func (f ProduceMessageForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			f.focused = (f.focused - 1 + len(f.inputs)) % len(f.inputs)
 			cmd = f.inputs[f.focused].input.Focus()
 			return f, cmd
+		case "left", "right", "h", "l":
+			if fi := &f.inputs[f.focused]; fi.picker() {
+				delta := 1
+				if msg.String() == "left" || msg.String() == "h" {
+					delta = len(fi.choices) - 1
+				}
+				fi.choice = (fi.choice + delta) % len(fi.choices)
+				return f, nil
+			}
 		case "enter":
 			values := ProduceMessageValues{}
 
 			for _, fi := range f.inputs {
 				switch fi.field {
 				case "PartitionNumber":
-					values.PartitionNumber = fi.input.Value()
+					values.PartitionNumber = fi.value()
 				case "KeySerde":
-					values.KeySerde = fi.input.Value()
+					values.KeySerde = fi.value()
 				case "ValueSerde":
-					values.ValueSerde = fi.input.Value()
+					values.ValueSerde = fi.value()
 				case "Key":
-					values.Key = fi.input.Value()
+					values.Key = fi.value()
 				case "Value":
-					values.Value = fi.input.Value()
+					values.Value = fi.value()
 				case "Headers":
-					values.Headers = fi.input.Value()
+					values.Headers = fi.value()
 				}
 			}
 
@@ -135,6 +181,9 @@ func (f ProduceMessageForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return f, nil
 		}
 	}
+	if f.inputs[f.focused].picker() {
+		return f, nil
+	}
 	ti, cmd := f.inputs[f.focused].input.Update(msg)
 	f.inputs[f.focused].input = ti
 	return f, cmd
@@ -151,12 +200,22 @@ func (f ProduceMessageForm) View() string {
 	for i, fi := range f.inputs {
 		label := labelStyle.Render(labels[i])
 		input := fi.input.View()
+		if fi.picker() {
+			style := TabStyle
+			if i == f.focused {
+				style = TabActiveStyle
+			}
+			input = style.Render("‹ " + fi.value() + " ›")
+		}
 		renderedInputs = append(renderedInputs, label)
 		renderedInputs = append(renderedInputs, input)
 	}
+	if f.err != "" {
+		renderedInputs = append(renderedInputs, "", FormErrorStyle.Render("✗ "+f.err))
+	}
 
 	help := FormHelpStyle.Render(
-		"enter: produce message • esc: cancel • tab: switch focus",
+		"enter: produce message • esc: cancel • tab: switch focus • ←/→: change serde",
 	)
 
 	content := lipgloss.JoinVertical(
@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type ProfileItem struct {
+	Name    string
+	Brokers []string
+	Active  bool
+}
+
+func (p ProfileItem) Title() string {
+	if p.Active {
+		return p.Name + " (connected)"
+	}
+	return p.Name
+}
+
+func (p ProfileItem) Description() string { return strings.Join(p.Brokers, ",") }
+func (p ProfileItem) FilterValue() string { return p.Name }
+
+type ProfilePicker struct {
+	list list.Model
+}
+
+type ProfileSelectedMsg struct {
+	Name string
+}
+
+func NewProfilePicker(title string, profiles []ProfileItem) ProfilePicker {
+	items := make([]list.Item, len(profiles))
+	for i, p := range profiles {
+		items[i] = p
+	}
+
+	l := list.New(items, list.NewDefaultDelegate(), 46, 14)
+	l.Title = title
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(true)
+	l.SetShowHelp(false)
+
+	return ProfilePicker{list: l}
+}
+
+func (f ProfilePicker) Init() tea.Cmd { return nil }
+
+// Filtering reports whether the filter is being typed, in which case esc
+// clears it rather than cancelling the picker.
+func (f ProfilePicker) Filtering() bool { return f.list.FilterState() == list.Filtering }
+
+func (f ProfilePicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && !f.Filtering() {
+		switch keyMsg.String() {
+		case "enter":
+			if item, ok := f.list.SelectedItem().(ProfileItem); ok {
+				return f, func() tea.Msg {
+					return ProfileSelectedMsg{Name: item.Name}
+				}
+			}
+			return f, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	f.list, cmd = f.list.Update(msg)
+	return f, cmd
+}
+
+func (f ProfilePicker) View() string {
+	help := FormHelpStyle.Render("enter: connect • /: filter • esc: cancel")
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		f.list.View(),
+		help,
+	)
+	return FormBoxStyle.Render(content)
+}
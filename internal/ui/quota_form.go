@@ -0,0 +1,233 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// quotaDefaultName is typed into an entity field to target the type's
+// default rather than a named entity.
+const quotaDefaultName = "<default>"
+
+var quotaEntityTypes = []string{QuotaUser, QuotaClientID, QuotaIP}
+
+var quotaFormLabels = []string{
+	"User",
+	"Client ID",
+	"IP",
+	"Producer byte rate (bytes/s)",
+	"Consumer byte rate (bytes/s)",
+	"Request percentage (% of a thread)",
+	"Connection creation rate (per second)",
+}
+
+// QuotaForm adds quotas to a new entity or alters an existing entity's.
+// Clearing a value removes that quota.
+type QuotaForm struct {
+	editing  bool
+	original ClientQuota
+	inputs   []textinput.Model
+	focused  int
+	err      string
+}
+
+type QuotaSubmittedMsg struct {
+	Entity []QuotaEntityPart
+	Set    map[string]float64
+	Remove []string
+}
+
+// NewQuotaForm edits the given quota, or creates one when it has no entity.
+// An existing entity can't be changed, only its values.
+func NewQuotaForm(quota ClientQuota) QuotaForm {
+	inputs := make([]textinput.Model, len(quotaFormLabels))
+	for i := range inputs {
+		input := textinput.New()
+		input.Width = 44
+		inputs[i] = input
+	}
+	for i := range quotaEntityTypes {
+		inputs[i].Placeholder = "not part of the entity, or " + quotaDefaultName
+	}
+	for i, key := range quotaKeys {
+		input := &inputs[len(quotaEntityTypes)+i]
+		input.Placeholder = "no quota"
+		if value, ok := quota.Values[key]; ok {
+			input.SetValue(strconv.FormatFloat(value, 'f', -1, 64))
+		}
+	}
+
+	f := QuotaForm{original: quota, inputs: inputs}
+	if len(quota.Entity) > 0 {
+		f.editing = true
+		f.focused = len(quotaEntityTypes)
+	}
+	inputs[f.focused].Focus()
+	return f
+}
+
+// first is the first input that can take focus.
+func (f QuotaForm) first() int {
+	if f.editing {
+		return len(quotaEntityTypes)
+	}
+	return 0
+}
+
+func (f QuotaForm) Init() tea.Cmd { return textinput.Blink }
+
+func (f QuotaForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "tab", "shift+tab":
+			f.inputs[f.focused].Blur()
+			first, n := f.first(), len(f.inputs)-f.first()
+			if msg.String() == "tab" {
+				f.focused = first + (f.focused-first+1)%n
+			} else {
+				f.focused = first + (f.focused-first-1+n)%n
+			}
+			return f, f.inputs[f.focused].Focus()
+		case "enter":
+			submitted, err := f.values()
+			if err != nil {
+				f.err = err.Error()
+				return f, nil
+			}
+			f.err = ""
+			return f, func() tea.Msg { return submitted }
+		case "esc":
+			return f, nil
+		}
+	}
+
+	f.inputs[f.focused], cmd = f.inputs[f.focused].Update(msg)
+	return f, cmd
+}
+
+func (f QuotaForm) values() (QuotaSubmittedMsg, error) {
+	submitted := QuotaSubmittedMsg{
+		Entity: f.original.Entity,
+		Set:    make(map[string]float64),
+	}
+
+	if !f.editing {
+		for i, t := range quotaEntityTypes {
+			name := strings.TrimSpace(f.inputs[i].Value())
+			switch name {
+			case "":
+				continue
+			case quotaDefaultName:
+				submitted.Entity = append(submitted.Entity, QuotaEntityPart{Type: t, Default: true})
+			default:
+				submitted.Entity = append(submitted.Entity, QuotaEntityPart{Type: t, Name: name})
+			}
+		}
+		if len(submitted.Entity) == 0 {
+			return submitted, fmt.Errorf("enter a user, client id or IP")
+		}
+		for _, p := range submitted.Entity {
+			if p.Type == QuotaIP && len(submitted.Entity) > 1 {
+				return submitted, fmt.Errorf("IP quotas can't be combined with a user or client id")
+			}
+		}
+	}
+
+	for i, key := range quotaKeys {
+		v := strings.TrimSpace(f.inputs[len(quotaEntityTypes)+i].Value())
+		if v == "" {
+			if _, ok := f.original.Values[key]; ok {
+				submitted.Remove = append(submitted.Remove, key)
+			}
+			continue
+		}
+		value, err := strconv.ParseFloat(v, 64)
+		if err != nil || value <= 0 {
+			return submitted, fmt.Errorf("%s must be a positive number, got %q", key, v)
+		}
+		if old, ok := f.original.Values[key]; !ok || old != value {
+			submitted.Set[key] = value
+		}
+	}
+
+	if len(submitted.Set) == 0 && len(submitted.Remove) == 0 {
+		return submitted, fmt.Errorf("nothing to change")
+	}
+	return submitted, nil
+}
+
+func (f QuotaForm) View() string {
+	title := FormTitleStyle.Render("Add Client Quota")
+	help := "enter: save • tab: next field • esc: cancel"
+	if f.editing {
+		title = FormTitleStyle.Render("Edit Client Quota")
+		help = "enter: save • tab: next field • clear a value to remove it • esc: cancel"
+	}
+
+	parts := []string{title}
+	if f.editing {
+		parts = append(parts, "Entity: "+f.original.EntityString(), "")
+	}
+	for i, input := range f.inputs {
+		if f.editing && i < len(quotaEntityTypes) {
+			continue
+		}
+		parts = append(parts, quotaFormLabels[i]+":", input.View())
+	}
+	if f.err != "" {
+		parts = append(parts, "", FormErrorStyle.Render("✗ "+f.err))
+	}
+	parts = append(parts, FormHelpStyle.Render(help))
+
+	return FormBoxStyle.Width(60).Render(lipgloss.JoinVertical(lipgloss.Left, parts...))
+}
+
+type DeleteQuotaForm struct {
+	quota ClientQuota
+}
+
+type QuotaDeleteMsg struct {
+	Confirmed bool
+}
+
+func NewDeleteQuotaForm(quota ClientQuota) DeleteQuotaForm {
+	return DeleteQuotaForm{quota: quota}
+}
+
+func (f DeleteQuotaForm) Init() tea.Cmd { return nil }
+
+func (f DeleteQuotaForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "y", "Y":
+			return f, func() tea.Msg { return QuotaDeleteMsg{Confirmed: true} }
+		case "n", "N", "esc":
+			return f, func() tea.Msg { return QuotaDeleteMsg{Confirmed: false} }
+		}
+	}
+	return f, nil
+}
+
+func (f DeleteQuotaForm) View() string {
+	items := make([]string, 0, len(f.quota.Values))
+	for key, value := range f.quota.Values {
+		items = append(items, fmt.Sprintf("%s = %s", key, FormatQuota(key, value)))
+	}
+	sort.Strings(items)
+
+	return renderDeleteConfirm(
+		FormBoxStyle,
+		"Remove Client Quotas",
+		fmt.Sprintf("Are you sure you want to remove every quota on %s:", f.quota.EntityString()),
+		items,
+	)
+}
@@ -0,0 +1,269 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Quota entity types and the quotas this view knows how to show and edit.
+const (
+	QuotaUser     = "user"
+	QuotaClientID = "client-id"
+	QuotaIP       = "ip"
+
+	QuotaProducerByteRate       = "producer_byte_rate"
+	QuotaConsumerByteRate       = "consumer_byte_rate"
+	QuotaRequestPercentage      = "request_percentage"
+	QuotaConnectionCreationRate = "connection_creation_rate"
+)
+
+var quotaKeys = []string{
+	QuotaProducerByteRate,
+	QuotaConsumerByteRate,
+	QuotaRequestPercentage,
+	QuotaConnectionCreationRate,
+}
+
+// QuotaEntityPart is one component of a quota entity. Default marks the
+// default for the type, which applies to every name without its own quota.
+type QuotaEntityPart struct {
+	Type    string
+	Name    string
+	Default bool
+}
+
+func (p QuotaEntityPart) String() string {
+	if p.Default {
+		return p.Type + "=<default>"
+	}
+	return p.Type + "=" + p.Name
+}
+
+// ClientQuota is the quotas set on one entity, such as a user, a user and
+// client id pair, or an IP.
+type ClientQuota struct {
+	Entity []QuotaEntityPart
+	Values map[string]float64
+}
+
+func (q ClientQuota) EntityString() string {
+	parts := make([]string, len(q.Entity))
+	for i, p := range q.Entity {
+		parts[i] = p.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// FormatQuota renders a quota value in its unit.
+func FormatQuota(key string, value float64) string {
+	switch key {
+	case QuotaProducerByteRate, QuotaConsumerByteRate:
+		return FormatBytes(int64(value)) + "/s"
+	case QuotaRequestPercentage:
+		return fmt.Sprintf("%g%%", value)
+	case QuotaConnectionCreationRate:
+		return fmt.Sprintf("%g/s", value)
+	}
+	return fmt.Sprintf("%g", value)
+}
+
+// QuotaEditRequestedMsg asks for the quota form, for a new entity when
+// Quota has no entity.
+type QuotaEditRequestedMsg struct {
+	Quota ClientQuota
+}
+
+type QuotaDeleteRequestedMsg struct {
+	Quota ClientQuota
+}
+
+type QuotasViewModel struct {
+	quotas  []ClientQuota
+	visible []ClientQuota
+	loaded  bool
+	err     error
+
+	table       table.Model
+	filtering   bool
+	filterInput textinput.Model
+
+	width  int
+	height int
+}
+
+func NewQuotasViewModel(width, height int) *QuotasViewModel {
+	filterInput := textinput.New()
+	filterInput.Placeholder = "Filter entities..."
+	filterInput.Width = 40
+
+	vm := &QuotasViewModel{
+		table:       newTable(nil),
+		filterInput: filterInput,
+	}
+	vm.resize(width, height)
+	return vm
+}
+
+func (v *QuotasViewModel) SetQuotas(quotas []ClientQuota, err error) {
+	v.loaded = true
+	v.err = err
+	if err == nil {
+		v.quotas = quotas
+	}
+	v.refreshRows()
+}
+
+// Typing reports whether keys are going to a text input, so the parent
+// should not treat them as shortcuts.
+func (v *QuotasViewModel) Typing() bool {
+	return v.filtering
+}
+
+func (v *QuotasViewModel) resize(width, height int) {
+	v.width = width
+	v.height = height
+	v.table.SetColumns(fitColumns([]table.Column{
+		{Title: "Entity", Width: 0},
+		{Title: "Produce", Width: 12},
+		{Title: "Consume", Width: 12},
+		{Title: "Request %", Width: 9},
+		{Title: "Conn/s", Width: 8},
+		{Title: "Other", Width: 20},
+	}, width, 0))
+	v.table.SetHeight(max(height-6, 3))
+}
+
+func (v *QuotasViewModel) refreshRows() {
+	filter := strings.ToLower(v.filterInput.Value())
+
+	v.visible = v.visible[:0]
+	rows := make([]table.Row, 0, len(v.quotas))
+	for _, q := range v.quotas {
+		entity := q.EntityString()
+		if filter != "" && !strings.Contains(strings.ToLower(entity), filter) {
+			continue
+		}
+		v.visible = append(v.visible, q)
+
+		row := table.Row{entity}
+		for _, key := range quotaKeys {
+			cell := "-"
+			if value, ok := q.Values[key]; ok {
+				cell = FormatQuota(key, value)
+			}
+			row = append(row, cell)
+		}
+
+		var other []string
+		for key, value := range q.Values {
+			if !isKnownQuota(key) {
+				other = append(other, fmt.Sprintf("%s=%g", key, value))
+			}
+		}
+		sort.Strings(other)
+		rows = append(rows, append(row, strings.Join(other, " ")))
+	}
+	v.table.SetRows(rows)
+	if v.table.Cursor() >= len(rows) {
+		v.table.SetCursor(max(len(rows)-1, 0))
+	}
+}
+
+func isKnownQuota(key string) bool {
+	for _, k := range quotaKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *QuotasViewModel) selected() (ClientQuota, bool) {
+	cursor := v.table.Cursor()
+	if cursor < 0 || cursor >= len(v.visible) {
+		return ClientQuota{}, false
+	}
+	return v.visible[cursor], true
+}
+
+func (v *QuotasViewModel) Init() tea.Cmd {
+	return nil
+}
+
+func (v *QuotasViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.resize(msg.Width, msg.Height)
+		return v, nil
+
+	case tea.KeyMsg:
+		if v.filtering {
+			switch msg.String() {
+			case "enter", "esc":
+				if msg.String() == "esc" {
+					v.filterInput.SetValue("")
+				}
+				v.filtering = false
+				v.filterInput.Blur()
+				v.refreshRows()
+				return v, nil
+			}
+			v.filterInput, cmd = v.filterInput.Update(msg)
+			v.refreshRows()
+			return v, cmd
+		}
+
+		switch msg.String() {
+		case "/":
+			v.filtering = true
+			return v, v.filterInput.Focus()
+		case "r":
+			return v, func() tea.Msg { return RefreshMsg{} }
+		case "c":
+			return v, func() tea.Msg { return QuotaEditRequestedMsg{} }
+		case "e", "enter":
+			if q, ok := v.selected(); ok {
+				return v, func() tea.Msg { return QuotaEditRequestedMsg{Quota: q} }
+			}
+			return v, nil
+		case "x":
+			if q, ok := v.selected(); ok {
+				return v, func() tea.Msg { return QuotaDeleteRequestedMsg{Quota: q} }
+			}
+			return v, nil
+		}
+	}
+
+	v.table, cmd = v.table.Update(msg)
+	return v, cmd
+}
+
+func (v *QuotasViewModel) View() string {
+	var statusBar string
+	switch {
+	case v.filtering:
+		statusBar = lipgloss.NewStyle().Foreground(AccentColor).Render("Filter: ") + v.filterInput.View()
+	case !v.loaded:
+		statusBar = lipgloss.NewStyle().Foreground(SubtleColor).Render("⏳ Loading client quotas...")
+	case v.err != nil:
+		statusBar = partitionErrorStyle.Render(fmt.Sprintf("Failed to load client quotas: %v", v.err))
+	case v.filterInput.Value() != "":
+		statusBar = lipgloss.NewStyle().Foreground(SubtleColor).Render(
+			fmt.Sprintf("🔍 Filter: '%s' • %d of %d entities", v.filterInput.Value(), len(v.visible), len(v.quotas)))
+	default:
+		statusBar = lipgloss.NewStyle().Foreground(SubtleColor).Render(fmt.Sprintf("%d entities with quotas", len(v.quotas)))
+	}
+
+	if v.loaded && v.err == nil && len(v.quotas) == 0 {
+		return lipgloss.JoinVertical(lipgloss.Left, statusBar, "", emptyStateStyle.Render("No client quotas are set. Press c to add one."))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, statusBar, v.table.View())
+}
@@ -0,0 +1,478 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type ResetStrategy int
+
+const (
+	ResetToEarliest ResetStrategy = iota
+	ResetToLatest
+	ResetToDatetime
+	ResetToOffset
+	ResetShiftBy
+	resetStrategyCount
+)
+
+func (s ResetStrategy) String() string {
+	switch s {
+	case ResetToEarliest:
+		return "to earliest"
+	case ResetToLatest:
+		return "to latest"
+	case ResetToDatetime:
+		return "to datetime"
+	case ResetToOffset:
+		return "to offset"
+	case ResetShiftBy:
+		return "shift by"
+	}
+	return "unknown"
+}
+
+func (s ResetStrategy) needsValue() bool {
+	return s == ResetToDatetime || s == ResetToOffset || s == ResetShiftBy
+}
+
+func (s ResetStrategy) placeholder() string {
+	switch s {
+	case ResetToDatetime:
+		return "2024-01-31 14:00:00 (local) or RFC 3339"
+	case ResetToOffset:
+		return "offset, e.g. 1200"
+	case ResetShiftBy:
+		return "delta, e.g. -500 or +100"
+	}
+	return ""
+}
+
+// OffsetResetRequest describes a reset before it is resolved into concrete
+// offsets per partition.
+type OffsetResetRequest struct {
+	Group      string
+	Partitions map[string][]int32
+	Strategy   ResetStrategy
+	Timestamp  time.Time // ResetToDatetime
+	Offset     int64     // the target for ResetToOffset, the delta for ResetShiftBy
+}
+
+type OffsetResetRow struct {
+	Topic     string
+	Partition int32
+	Old       int64 // -1 when the group has no commit for the partition
+	New       int64
+	Err       error
+}
+
+// OffsetResetPreviewMsg asks for a dry run of the request.
+type OffsetResetPreviewMsg struct {
+	Request OffsetResetRequest
+}
+
+// OffsetResetConfirmedMsg carries the previewed offsets to commit.
+type OffsetResetConfirmedMsg struct {
+	Group string
+	Rows  []OffsetResetRow
+}
+
+type resetMode int
+
+const (
+	resetSelect resetMode = iota
+	resetValue
+	resetPlanning
+	resetPreview
+	resetApplying
+)
+
+var resetDatetimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+func parseResetDatetime(s string) (time.Time, error) {
+	for _, layout := range resetDatetimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q as a datetime; use YYYY-MM-DD [HH:MM[:SS]] or RFC 3339", s)
+}
+
+type ResetOffsetsViewModel struct {
+	group      string
+	partitions []GroupPartitionLag
+	selected   []bool
+	strategy   ResetStrategy
+	valueInput textinput.Model
+
+	mode    resetMode
+	preview []OffsetResetRow
+	status  string
+	err     bool
+
+	table table.Model
+
+	width  int
+	height int
+}
+
+func NewResetOffsetsViewModel(group string, partitions []GroupPartitionLag, width, height int) *ResetOffsetsViewModel {
+	valueInput := textinput.New()
+	valueInput.Width = 40
+
+	selected := make([]bool, len(partitions))
+	for i := range selected {
+		selected[i] = true
+	}
+
+	vm := &ResetOffsetsViewModel{
+		group:      group,
+		partitions: partitions,
+		selected:   selected,
+		valueInput: valueInput,
+		table:      newTable(nil),
+	}
+	vm.resize(width, height)
+	return vm
+}
+
+// SetPreview shows the result of a dry run, or the reason it failed.
+func (v *ResetOffsetsViewModel) SetPreview(rows []OffsetResetRow, err error) {
+	if err != nil {
+		v.mode = resetSelect
+		v.setError(err)
+		v.refreshRows()
+		return
+	}
+	v.mode = resetPreview
+	v.preview = rows
+	v.status = ""
+	v.refreshRows()
+}
+
+// SetApplyError returns to the preview after a failed commit.
+func (v *ResetOffsetsViewModel) SetApplyError(err error) {
+	v.mode = resetPreview
+	v.setError(err)
+}
+
+func (v *ResetOffsetsViewModel) setError(err error) {
+	v.status = err.Error()
+	v.err = true
+}
+
+func (v *ResetOffsetsViewModel) setStatus(status string) {
+	v.status = status
+	v.err = false
+}
+
+func (v *ResetOffsetsViewModel) columns() []table.Column {
+	if v.mode == resetPreview || v.mode == resetApplying {
+		return fitColumns([]table.Column{
+			{Title: "Topic", Width: 0},
+			{Title: "Partition", Width: 9},
+			{Title: "Current", Width: 14},
+			{Title: "New", Width: 14},
+			{Title: "Change", Width: 30},
+		}, v.width, 0)
+	}
+	return fitColumns([]table.Column{
+		{Title: "", Width: 1},
+		{Title: "Topic", Width: 0},
+		{Title: "Partition", Width: 9},
+		{Title: "Committed", Width: 14},
+		{Title: "End", Width: 14},
+		{Title: "Lag", Width: 12},
+	}, v.width, 1)
+}
+
+func (v *ResetOffsetsViewModel) resize(width, height int) {
+	v.width = width
+	v.height = height
+	v.table.SetHeight(max(height-10, 3))
+	v.refreshRows()
+}
+
+func formatOffset(offset int64) string {
+	if offset < 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d", offset)
+}
+
+func (v *ResetOffsetsViewModel) refreshRows() {
+	var rows []table.Row
+	if v.mode == resetPreview || v.mode == resetApplying {
+		for _, r := range v.preview {
+			change := ""
+			switch {
+			case r.Err != nil:
+				change = "error: " + r.Err.Error()
+			case r.Old < 0:
+				change = "new commit"
+			case r.New == r.Old:
+				change = "unchanged"
+			default:
+				change = fmt.Sprintf("%+d", r.New-r.Old)
+			}
+			newOffset := formatOffset(r.New)
+			if r.Err != nil {
+				newOffset = "-"
+			}
+			rows = append(rows, table.Row{
+				r.Topic,
+				fmt.Sprintf("%d", r.Partition),
+				formatOffset(r.Old),
+				newOffset,
+				change,
+			})
+		}
+	} else {
+		for i, p := range v.partitions {
+			marker := ""
+			if v.selected[i] {
+				marker = "✓"
+			}
+			lag := fmt.Sprintf("%d", p.Lag)
+			if p.Lag < 0 {
+				lag = "?"
+			}
+			rows = append(rows, table.Row{
+				marker,
+				p.Topic,
+				fmt.Sprintf("%d", p.Partition),
+				formatOffset(p.Committed),
+				fmt.Sprintf("%d", p.End),
+				lag,
+			})
+		}
+	}
+
+	// Clear the rows first: the preview and selection tables have a
+	// different number of columns.
+	v.table.SetRows(nil)
+	v.table.SetColumns(v.columns())
+	v.table.SetRows(rows)
+	if v.table.Cursor() >= len(rows) {
+		v.table.SetCursor(max(len(rows)-1, 0))
+	}
+}
+
+func (v *ResetOffsetsViewModel) selectedCount() int {
+	n := 0
+	for _, s := range v.selected {
+		if s {
+			n++
+		}
+	}
+	return n
+}
+
+// request validates the form and builds the reset to preview.
+func (v *ResetOffsetsViewModel) request() (OffsetResetRequest, error) {
+	req := OffsetResetRequest{
+		Group:      v.group,
+		Partitions: make(map[string][]int32),
+		Strategy:   v.strategy,
+	}
+	for i, p := range v.partitions {
+		if v.selected[i] {
+			req.Partitions[p.Topic] = append(req.Partitions[p.Topic], p.Partition)
+		}
+	}
+	if len(req.Partitions) == 0 {
+		return req, errors.New("select at least one partition")
+	}
+
+	value := strings.TrimSpace(v.valueInput.Value())
+	switch v.strategy {
+	case ResetToDatetime:
+		t, err := parseResetDatetime(value)
+		if err != nil {
+			return req, err
+		}
+		req.Timestamp = t
+	case ResetToOffset:
+		offset, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || offset < 0 {
+			return req, fmt.Errorf("offset must be a non-negative integer, got %q", value)
+		}
+		req.Offset = offset
+	case ResetShiftBy:
+		delta, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || delta == 0 {
+			return req, fmt.Errorf("shift must be a non-zero integer such as -500 or +100, got %q", value)
+		}
+		req.Offset = delta
+	}
+	return req, nil
+}
+
+func (v *ResetOffsetsViewModel) setStrategy(s ResetStrategy) {
+	v.strategy = s
+	v.valueInput.SetValue("")
+	v.valueInput.Placeholder = s.placeholder()
+	v.status = ""
+}
+
+func (v *ResetOffsetsViewModel) Init() tea.Cmd {
+	return nil
+}
+
+func (v *ResetOffsetsViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.resize(msg.Width, msg.Height)
+		return v, nil
+
+	case tea.KeyMsg:
+		switch v.mode {
+		case resetValue:
+			switch msg.String() {
+			case "enter", "tab", "esc":
+				v.valueInput.Blur()
+				v.mode = resetSelect
+				return v, nil
+			}
+			v.valueInput, cmd = v.valueInput.Update(msg)
+			return v, cmd
+
+		case resetPlanning, resetApplying:
+			return v, nil
+
+		case resetPreview:
+			switch msg.String() {
+			case "y", "Y":
+				var rows []OffsetResetRow
+				for _, r := range v.preview {
+					if r.Err == nil {
+						rows = append(rows, r)
+					}
+				}
+				if len(rows) == 0 {
+					v.setError(errors.New("no partition can be reset"))
+					return v, nil
+				}
+				v.mode = resetApplying
+				v.setStatus("Committing offsets...")
+				group := v.group
+				return v, func() tea.Msg { return OffsetResetConfirmedMsg{Group: group, Rows: rows} }
+			case "n", "N", "esc":
+				v.mode = resetSelect
+				v.status = ""
+				v.refreshRows()
+				return v, nil
+			}
+			v.table, cmd = v.table.Update(msg)
+			return v, cmd
+		}
+
+		switch msg.String() {
+		case "esc":
+			return v, func() tea.Msg { return BackMsg{} }
+		case " ":
+			if cursor := v.table.Cursor(); cursor >= 0 && cursor < len(v.selected) {
+				v.selected[cursor] = !v.selected[cursor]
+				v.refreshRows()
+			}
+			return v, nil
+		case "a":
+			all := v.selectedCount() < len(v.selected)
+			for i := range v.selected {
+				v.selected[i] = all
+			}
+			v.refreshRows()
+			return v, nil
+		case "right", "l":
+			v.setStrategy((v.strategy + 1) % resetStrategyCount)
+			return v, nil
+		case "left", "h":
+			v.setStrategy((v.strategy - 1 + resetStrategyCount) % resetStrategyCount)
+			return v, nil
+		case "tab":
+			if !v.strategy.needsValue() {
+				return v, nil
+			}
+			v.mode = resetValue
+			return v, v.valueInput.Focus()
+		case "enter":
+			req, err := v.request()
+			if err != nil {
+				v.setError(err)
+				return v, nil
+			}
+			v.mode = resetPlanning
+			v.setStatus("Calculating new offsets...")
+			return v, func() tea.Msg { return OffsetResetPreviewMsg{Request: req} }
+		}
+	}
+
+	v.table, cmd = v.table.Update(msg)
+	return v, cmd
+}
+
+func (v *ResetOffsetsViewModel) strategyBar() string {
+	label := lipgloss.NewStyle().Foreground(AccentColor).Padding(0, 1)
+
+	bar := label.Render("Strategy:") + TabActiveStyle.Render("‹ "+v.strategy.String()+" ›")
+	if v.strategy.needsValue() {
+		bar += label.Render("Value:") + v.valueInput.View()
+	}
+	return bar
+}
+
+func (v *ResetOffsetsViewModel) View() string {
+	headerText := fmt.Sprintf("⟲ Reset offsets • %s", v.group)
+	if v.mode == resetPreview || v.mode == resetApplying {
+		changed := 0
+		for _, r := range v.preview {
+			if r.Err == nil && r.New != r.Old {
+				changed++
+			}
+		}
+		headerText += fmt.Sprintf(" • dry run: %s, %d of %d partitions change", v.strategy, changed, len(v.preview))
+	} else {
+		headerText += fmt.Sprintf(" • %d of %d partitions selected", v.selectedCount(), len(v.partitions))
+	}
+	header := HeaderStyle.Width(v.width).Render(headerText)
+
+	status := configStatusStyle.Render(v.status)
+	if v.err {
+		status = partitionErrorStyle.Padding(0, 1).Render(v.status)
+	}
+
+	var help string
+	switch v.mode {
+	case resetValue:
+		help = "enter/tab/esc: done editing"
+	case resetPreview:
+		help = "↑/↓ j/k: navigate • y: commit these offsets • n/esc: back to selection"
+	case resetPlanning, resetApplying:
+		help = "please wait..."
+	default:
+		help = "↑/↓ j/k: navigate • space: toggle partition • a: toggle all • ←/→: strategy • tab: edit value • enter: preview • esc: cancel"
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		v.strategyBar(),
+		status,
+		v.table.View(),
+		HelpStyle.Render(help),
+	)
+}
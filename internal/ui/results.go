@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ItemResult is the outcome of one item in a batch operation that can
+// partially fail.
+type ItemResult struct {
+	Name string
+	Err  error
+}
+
+type ResultsClosedMsg struct{}
+
+// ResultsForm lists the outcome of every item in a batch operation.
+type ResultsForm struct {
+	title   string
+	results []ItemResult
+}
+
+func NewResultsForm(title string, results []ItemResult) ResultsForm {
+	return ResultsForm{title: title, results: results}
+}
+
+func (f ResultsForm) Init() tea.Cmd { return nil }
+
+func (f ResultsForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "enter", "esc", "q":
+			return f, func() tea.Msg { return ResultsClosedMsg{} }
+		}
+	}
+	return f, nil
+}
+
+// maxListedResults caps the lines in the results box. Failures are listed
+// before successes so they are never the ones cut off.
+const maxListedResults = 15
+
+func (f ResultsForm) View() string {
+	var failed int
+	var failures, successes []string
+	for _, r := range f.results {
+		if r.Err != nil {
+			failed++
+			failures = append(failures, partitionErrorStyle.Render("✗ "+r.Name)+"\n    "+r.Err.Error())
+			continue
+		}
+		successes = append(successes, configNewValueStyle.Render("✓ "+r.Name))
+	}
+
+	lines := failures
+	room := max(maxListedResults-len(failures), 0)
+	if len(successes) > room {
+		lines = append(lines, successes[:room]...)
+		lines = append(lines, fmt.Sprintf("…and %d more succeeded", len(successes)-room))
+	} else {
+		lines = append(lines, successes...)
+	}
+
+	summary := fmt.Sprintf("%d succeeded", len(f.results)-failed)
+	if failed > 0 {
+		summary += partitionErrorStyle.Render(fmt.Sprintf(", %d failed", failed))
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		FormTitleStyle.Render(f.title),
+		summary,
+		"",
+		strings.Join(lines, "\n"),
+		FormHelpStyle.Render("enter/esc: close"),
+	)
+	return FormBoxStyle.Width(70).Render(content)
+}
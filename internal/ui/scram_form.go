@@ -0,0 +1,215 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	SCRAMSHA256 = "SCRAM-SHA-256"
+	SCRAMSHA512 = "SCRAM-SHA-512"
+
+	// The broker rejects iteration counts outside this range.
+	minSCRAMIterations = 4096
+	maxSCRAMIterations = 16384
+)
+
+var scramFormLabels = []string{
+	"User",
+	"Mechanism",
+	"Iterations",
+	"Password",
+	"Confirm Password",
+}
+
+const (
+	scramUser = iota
+	scramMechanism
+	scramIterations
+	scramPassword
+	scramConfirm
+)
+
+// SCRAMUserForm sets a user's password for one mechanism, creating the user
+// if needed. The password inputs are masked.
+type SCRAMUserForm struct {
+	rotating bool
+	inputs   []textinput.Model
+	focused  int
+	err      string
+}
+
+// SCRAMUserSubmittedMsg carries a password in the clear, so it must never be
+// logged; String redacts it in case it ends up formatted anyway.
+type SCRAMUserSubmittedMsg struct {
+	User       string
+	Mechanism  string
+	Iterations int32
+	Password   string
+}
+
+func (m SCRAMUserSubmittedMsg) String() string {
+	return fmt.Sprintf("{User:%s Mechanism:%s Iterations:%d Password:<redacted>}", m.User, m.Mechanism, m.Iterations)
+}
+
+// NewSCRAMUserForm creates a user when user is empty, and otherwise rotates
+// the password of that user's credential for mechanism, keeping its
+// iterations unless changed.
+func NewSCRAMUserForm(user, mechanism string, iterations int32) SCRAMUserForm {
+	inputs := make([]textinput.Model, len(scramFormLabels))
+	for i := range inputs {
+		input := textinput.New()
+		input.Width = 44
+		inputs[i] = input
+	}
+	inputs[scramUser].Placeholder = "alice"
+	inputs[scramMechanism].Placeholder = SCRAMSHA256 + " or " + SCRAMSHA512
+	inputs[scramIterations].Placeholder = fmt.Sprintf("%d-%d", minSCRAMIterations, maxSCRAMIterations)
+	inputs[scramIterations].CharLimit = 5
+	for _, i := range []int{scramPassword, scramConfirm} {
+		inputs[i].EchoMode = textinput.EchoPassword
+		inputs[i].EchoCharacter = '•'
+	}
+
+	f := SCRAMUserForm{inputs: inputs}
+	if mechanism == "" {
+		mechanism = SCRAMSHA512
+	}
+	inputs[scramMechanism].SetValue(mechanism)
+	if iterations == 0 {
+		iterations = 8192
+	}
+	inputs[scramIterations].SetValue(strconv.Itoa(int(iterations)))
+
+	if user != "" {
+		f.rotating = true
+		inputs[scramUser].SetValue(user)
+		f.focused = scramPassword
+	}
+	inputs[f.focused].Focus()
+	return f
+}
+
+func (f SCRAMUserForm) Init() tea.Cmd { return textinput.Blink }
+
+func (f SCRAMUserForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "tab", "shift+tab":
+			f.inputs[f.focused].Blur()
+			if msg.String() == "tab" {
+				f.focused = (f.focused + 1) % len(f.inputs)
+			} else {
+				f.focused = (f.focused - 1 + len(f.inputs)) % len(f.inputs)
+			}
+			return f, f.inputs[f.focused].Focus()
+		case "enter":
+			submitted, err := f.values()
+			if err != nil {
+				f.err = err.Error()
+				return f, nil
+			}
+			f.err = ""
+			return f, func() tea.Msg { return submitted }
+		case "esc":
+			return f, nil
+		}
+	}
+
+	f.inputs[f.focused], cmd = f.inputs[f.focused].Update(msg)
+	return f, cmd
+}
+
+func (f SCRAMUserForm) values() (SCRAMUserSubmittedMsg, error) {
+	submitted := SCRAMUserSubmittedMsg{
+		User:      strings.TrimSpace(f.inputs[scramUser].Value()),
+		Mechanism: strings.ToUpper(strings.TrimSpace(f.inputs[scramMechanism].Value())),
+		Password:  f.inputs[scramPassword].Value(),
+	}
+
+	if submitted.User == "" {
+		return submitted, fmt.Errorf("user is required")
+	}
+	if submitted.Mechanism != SCRAMSHA256 && submitted.Mechanism != SCRAMSHA512 {
+		return submitted, fmt.Errorf("mechanism must be %s or %s", SCRAMSHA256, SCRAMSHA512)
+	}
+
+	v := f.inputs[scramIterations].Value()
+	iterations, err := strconv.ParseInt(v, 10, 32)
+	if err != nil || iterations < minSCRAMIterations || iterations > maxSCRAMIterations {
+		return submitted, fmt.Errorf("iterations must be between %d and %d, got %q", minSCRAMIterations, maxSCRAMIterations, v)
+	}
+	submitted.Iterations = int32(iterations)
+
+	if submitted.Password == "" {
+		return submitted, fmt.Errorf("password is required")
+	}
+	if submitted.Password != f.inputs[scramConfirm].Value() {
+		return submitted, fmt.Errorf("passwords do not match")
+	}
+	return submitted, nil
+}
+
+func (f SCRAMUserForm) View() string {
+	title := FormTitleStyle.Render("Create SCRAM User")
+	help := "enter: create • tab: next field • esc: cancel"
+	if f.rotating {
+		title = FormTitleStyle.Render("Change Password")
+		help = "enter: change • tab: next field • esc: cancel"
+	}
+
+	parts := []string{title}
+	for i, input := range f.inputs {
+		parts = append(parts, scramFormLabels[i]+":", input.View())
+	}
+	if f.err != "" {
+		parts = append(parts, "", FormErrorStyle.Render("✗ "+f.err))
+	}
+	parts = append(parts, FormHelpStyle.Render(help))
+
+	return FormBoxStyle.Render(lipgloss.JoinVertical(lipgloss.Left, parts...))
+}
+
+type DeleteSCRAMForm struct {
+	user      string
+	mechanism string
+}
+
+type SCRAMDeleteMsg struct {
+	Confirmed bool
+}
+
+func NewDeleteSCRAMForm(user, mechanism string) DeleteSCRAMForm {
+	return DeleteSCRAMForm{user: user, mechanism: mechanism}
+}
+
+func (f DeleteSCRAMForm) Init() tea.Cmd { return nil }
+
+func (f DeleteSCRAMForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "y", "Y":
+			return f, func() tea.Msg { return SCRAMDeleteMsg{Confirmed: true} }
+		case "n", "N", "esc":
+			return f, func() tea.Msg { return SCRAMDeleteMsg{Confirmed: false} }
+		}
+	}
+	return f, nil
+}
+
+func (f DeleteSCRAMForm) View() string {
+	return renderDeleteConfirm(
+		FormBoxStyle,
+		"Delete SCRAM Credential",
+		fmt.Sprintf("Are you sure you want to delete %s's %s credential? Clients logging in with it will be rejected.", f.user, f.mechanism),
+		nil,
+	)
+}
@@ -0,0 +1,206 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SCRAMCredential is one user's password for one mechanism. Users the broker
+// could not describe have Err set and no mechanism.
+type SCRAMCredential struct {
+	User       string
+	Mechanism  string
+	Iterations int32
+	Err        error
+}
+
+// SCRAMUserRequestedMsg asks for the password form. An empty User creates a
+// new user; otherwise the user's password for Mechanism is rotated.
+type SCRAMUserRequestedMsg struct {
+	User       string
+	Mechanism  string
+	Iterations int32
+}
+
+type SCRAMDeleteRequestedMsg struct {
+	User      string
+	Mechanism string
+}
+
+type SCRAMUsersViewModel struct {
+	credentials []SCRAMCredential
+	visible     []SCRAMCredential
+	loaded      bool
+	err         error
+
+	table       table.Model
+	filtering   bool
+	filterInput textinput.Model
+
+	width  int
+	height int
+}
+
+func NewSCRAMUsersViewModel(width, height int) *SCRAMUsersViewModel {
+	filterInput := textinput.New()
+	filterInput.Placeholder = "Filter users..."
+	filterInput.Width = 40
+
+	vm := &SCRAMUsersViewModel{
+		table:       newTable(nil),
+		filterInput: filterInput,
+	}
+	vm.resize(width, height)
+	return vm
+}
+
+func (v *SCRAMUsersViewModel) SetCredentials(credentials []SCRAMCredential, err error) {
+	v.loaded = true
+	v.err = err
+	if err == nil {
+		v.credentials = credentials
+	}
+	v.refreshRows()
+}
+
+// Typing reports whether keys are going to a text input, so the parent
+// should not treat them as shortcuts.
+func (v *SCRAMUsersViewModel) Typing() bool {
+	return v.filtering
+}
+
+func (v *SCRAMUsersViewModel) resize(width, height int) {
+	v.width = width
+	v.height = height
+	v.table.SetColumns(fitColumns([]table.Column{
+		{Title: "User", Width: 0},
+		{Title: "Mechanism", Width: 16},
+		{Title: "Iterations", Width: 10},
+	}, width, 0))
+	v.table.SetHeight(max(height-6, 3))
+}
+
+func (v *SCRAMUsersViewModel) users() int {
+	users := make(map[string]bool, len(v.credentials))
+	for _, c := range v.credentials {
+		users[c.User] = true
+	}
+	return len(users)
+}
+
+func (v *SCRAMUsersViewModel) refreshRows() {
+	filter := strings.ToLower(v.filterInput.Value())
+
+	v.visible = v.visible[:0]
+	rows := make([]table.Row, 0, len(v.credentials))
+	for _, c := range v.credentials {
+		if filter != "" && !strings.Contains(strings.ToLower(c.User), filter) {
+			continue
+		}
+		v.visible = append(v.visible, c)
+
+		mechanism := c.Mechanism
+		iterations := fmt.Sprintf("%d", c.Iterations)
+		if c.Err != nil {
+			mechanism = partitionErrorStyle.Render(c.Err.Error())
+			iterations = ""
+		}
+		rows = append(rows, table.Row{c.User, mechanism, iterations})
+	}
+	v.table.SetRows(rows)
+	if v.table.Cursor() >= len(rows) {
+		v.table.SetCursor(max(len(rows)-1, 0))
+	}
+}
+
+func (v *SCRAMUsersViewModel) selected() (SCRAMCredential, bool) {
+	cursor := v.table.Cursor()
+	if cursor < 0 || cursor >= len(v.visible) {
+		return SCRAMCredential{}, false
+	}
+	return v.visible[cursor], true
+}
+
+func (v *SCRAMUsersViewModel) Init() tea.Cmd {
+	return nil
+}
+
+func (v *SCRAMUsersViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.resize(msg.Width, msg.Height)
+		return v, nil
+
+	case tea.KeyMsg:
+		if v.filtering {
+			switch msg.String() {
+			case "enter", "esc":
+				if msg.String() == "esc" {
+					v.filterInput.SetValue("")
+				}
+				v.filtering = false
+				v.filterInput.Blur()
+				v.refreshRows()
+				return v, nil
+			}
+			v.filterInput, cmd = v.filterInput.Update(msg)
+			v.refreshRows()
+			return v, cmd
+		}
+
+		switch msg.String() {
+		case "/":
+			v.filtering = true
+			return v, v.filterInput.Focus()
+		case "r":
+			return v, func() tea.Msg { return RefreshMsg{} }
+		case "c":
+			return v, func() tea.Msg { return SCRAMUserRequestedMsg{} }
+		case "p":
+			if c, ok := v.selected(); ok && c.Err == nil {
+				return v, func() tea.Msg {
+					return SCRAMUserRequestedMsg{User: c.User, Mechanism: c.Mechanism, Iterations: c.Iterations}
+				}
+			}
+			return v, nil
+		case "x":
+			if c, ok := v.selected(); ok && c.Err == nil {
+				return v, func() tea.Msg { return SCRAMDeleteRequestedMsg{User: c.User, Mechanism: c.Mechanism} }
+			}
+			return v, nil
+		}
+	}
+
+	v.table, cmd = v.table.Update(msg)
+	return v, cmd
+}
+
+func (v *SCRAMUsersViewModel) View() string {
+	var statusBar string
+	switch {
+	case v.filtering:
+		statusBar = lipgloss.NewStyle().Foreground(AccentColor).Render("Filter: ") + v.filterInput.View()
+	case !v.loaded:
+		statusBar = lipgloss.NewStyle().Foreground(SubtleColor).Render("⏳ Loading SCRAM users...")
+	case v.err != nil:
+		statusBar = partitionErrorStyle.Render(fmt.Sprintf("Failed to load SCRAM users: %v", v.err))
+	case v.filterInput.Value() != "":
+		statusBar = lipgloss.NewStyle().Foreground(SubtleColor).Render(
+			fmt.Sprintf("🔍 Filter: '%s' • %d of %d credentials", v.filterInput.Value(), len(v.visible), len(v.credentials)))
+	default:
+		statusBar = lipgloss.NewStyle().Foreground(SubtleColor).Render(
+			fmt.Sprintf("%d users • %d credentials", v.users(), len(v.credentials)))
+	}
+
+	if v.loaded && v.err == nil && len(v.credentials) == 0 {
+		return lipgloss.JoinVertical(lipgloss.Left, statusBar, "", emptyStateStyle.Render("No SCRAM users. Press c to create one."))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, statusBar, v.table.View())
+}
@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type StartKind int
+
+const (
+	StartEarliest StartKind = iota
+	StartLatest
+	StartOffset
+	StartTimestamp
+	StartLastN
+	startKindCount
+)
+
+func (k StartKind) String() string {
+	switch k {
+	case StartEarliest:
+		return "from beginning"
+	case StartLatest:
+		return "new messages only"
+	case StartOffset:
+		return "from offset"
+	case StartTimestamp:
+		return "from time"
+	case StartLastN:
+		return "last N per partition"
+	}
+	return "unknown"
+}
+
+func (k StartKind) needsValue() bool {
+	return k == StartOffset || k == StartTimestamp || k == StartLastN
+}
+
+func (k StartKind) placeholder() string {
+	switch k {
+	case StartOffset:
+		return "1200 for every partition, or 0:1200,3:50 per partition"
+	case StartTimestamp:
+		return "15m ago, 2h ago, 1d ago or 2024-01-31 14:00:00"
+	case StartLastN:
+		return "messages per partition, e.g. 100"
+	}
+	return ""
+}
+
+// StartPosition is where the message view starts reading a topic.
+type StartPosition struct {
+	Kind StartKind
+	// Offset is where StartOffset starts partitions missing from Offsets,
+	// -1 for the beginning.
+	Offset  int64
+	Offsets map[int32]int64
+	Time    time.Time // StartTimestamp
+	Count   int64     // StartLastN
+
+	input string // as typed, for the header
+}
+
+func (p StartPosition) String() string {
+	switch p.Kind {
+	case StartOffset, StartTimestamp:
+		return p.Kind.String() + " " + p.input
+	case StartLastN:
+		return fmt.Sprintf("last %d per partition", p.Count)
+	}
+	return p.Kind.String()
+}
+
+// parseStartPosition validates the picker's value for kind. Relative times
+// are resolved against now.
+func parseStartPosition(kind StartKind, value string, now time.Time) (StartPosition, error) {
+	value = strings.TrimSpace(value)
+	p := StartPosition{Kind: kind, Offset: -1, input: value}
+
+	switch kind {
+	case StartOffset:
+		offsets, err := parseStartOffsets(value)
+		if err != nil {
+			return p, err
+		}
+		if def, ok := offsets[-1]; ok {
+			p.Offset = def
+			delete(offsets, -1)
+		}
+		if len(offsets) > 0 {
+			p.Offsets = offsets
+		}
+	case StartTimestamp:
+		t, err := parseStartTime(value, now)
+		if err != nil {
+			return p, err
+		}
+		p.Time = t
+	case StartLastN:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || n <= 0 {
+			return p, fmt.Errorf("count must be a positive integer, got %q", value)
+		}
+		p.Count = n
+	}
+	return p, nil
+}
+
+// parseStartOffsets parses "1200" or "0:1200,3:50", or a mix of both. The
+// offset without a partition is returned under partition -1.
+func parseStartOffsets(value string) (map[int32]int64, error) {
+	if value == "" {
+		return nil, errors.New("enter an offset, or partition:offset pairs")
+	}
+
+	offsets := make(map[int32]int64)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		partition := int32(-1)
+		offsetStr := entry
+		if p, o, ok := strings.Cut(entry, ":"); ok {
+			n, err := strconv.ParseInt(strings.TrimSpace(p), 10, 32)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("partition must be a non-negative integer, got %q", p)
+			}
+			partition, offsetStr = int32(n), strings.TrimSpace(o)
+		}
+		offset, err := strconv.ParseInt(offsetStr, 10, 64)
+		if err != nil || offset < 0 {
+			return nil, fmt.Errorf("offset must be a non-negative integer, got %q", offsetStr)
+		}
+		if _, dup := offsets[partition]; dup {
+			if partition < 0 {
+				return nil, errors.New("only one offset can apply to every partition")
+			}
+			return nil, fmt.Errorf("partition %d is given twice", partition)
+		}
+		offsets[partition] = offset
+	}
+	return offsets, nil
+}
+
+// parseStartTime accepts a duration before now, such as "15m ago", "90s" or
+// "2d ago", or an absolute datetime as taken by the offset reset view.
+func parseStartTime(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, errors.New("enter a time, e.g. 15m ago")
+	}
+
+	ago := strings.TrimSpace(strings.TrimSuffix(value, "ago"))
+	if days, ok := strings.CutSuffix(ago, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if d, err := time.ParseDuration(ago); err == nil {
+		if d < 0 {
+			return time.Time{}, fmt.Errorf("%q is in the future", value)
+		}
+		return now.Add(-d), nil
+	}
+
+	t, err := parseResetDatetime(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("cannot parse %q; use a duration such as 15m ago, YYYY-MM-DD [HH:MM[:SS]] or RFC 3339", value)
+	}
+	return t, nil
+}
@@ -47,4 +47,18 @@ var (
 	FormHelpStyle = lipgloss.NewStyle().
 			Foreground(SubtleColor).
 			MarginTop(1)
+
+	TabStyle = lipgloss.NewStyle().
+			Foreground(SubtleColor).
+			Bold(false).
+			Padding(0, 1)
+
+	TabActiveStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("0")).
+			Background(AccentColor).
+			Padding(0, 1)
+
+	FormErrorStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("196")).
+			Width(46)
 )
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func newTable(columns []table.Column) table.Model {
+	styles := table.DefaultStyles()
+	styles.Header = styles.Header.
+		Foreground(AccentColor).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(BorderColor)
+	styles.Selected = styles.Selected.
+		Foreground(lipgloss.Color("0")).
+		Background(PrimaryColor)
+
+	return table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithStyles(styles),
+	)
+}
+
+// fitColumns gives the column at index flex whatever width the others leave
+// over, accounting for the one cell of padding either side of each column.
+func fitColumns(columns []table.Column, width int, flex int) []table.Column {
+	used := 0
+	for i, c := range columns {
+		used += 2
+		if i != flex {
+			used += c.Width
+		}
+	}
+
+	fitted := make([]table.Column, len(columns))
+	copy(fitted, columns)
+	fitted[flex].Width = max(width-used, 10)
+	return fitted
+}
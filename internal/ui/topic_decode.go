@@ -0,0 +1,144 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"mojosoftware.dev/lazykafka/internal/serde"
+)
+
+// decodedRecord is a record's key and value as the chosen deserializers
+// read them.
+type decodedRecord struct {
+	key, value             string
+	keyErr, valueErr       error
+	keyFormat, valueFormat string
+	pending                bool // still being decoded in the background
+}
+
+// RecordsDecodedMsg carries records decoded in the background, as a
+// deserializer may have to fetch a schema from the registry first.
+type RecordsDecodedMsg struct {
+	Topic      string
+	generation int
+	decoded    map[*kgo.Record]decodedRecord
+}
+
+// decodePayload returns "" for a nil key or value, leaving it to the
+// caller to show as null.
+func decodePayload(d serde.Deserializer, topic string, data []byte) (string, error) {
+	if data == nil {
+		return "", nil
+	}
+	return serde.Decode(d, topic, data)
+}
+
+// decodeCmd deserializes records with the current deserializers. Cards
+// are rendered on every update, so View only ever reads the results.
+func (t *TopicViewModel) decodeCmd(records []*kgo.Record) tea.Cmd {
+	if len(records) == 0 {
+		return nil
+	}
+	topic, generation := t.topicName, t.generation
+	keyFormat, valueFormat := t.keySerde, t.valueSerde
+	key, value := t.deserializers.Get(keyFormat), t.deserializers.Get(valueFormat)
+	return func() tea.Msg {
+		decoded := make(map[*kgo.Record]decodedRecord, len(records))
+		for _, r := range records {
+			d := decodedRecord{keyFormat: keyFormat, valueFormat: valueFormat}
+			d.key, d.keyErr = decodePayload(key, topic, r.Key)
+			d.value, d.valueErr = decodePayload(value, topic, r.Value)
+			decoded[r] = d
+		}
+		return RecordsDecodedMsg{Topic: topic, generation: generation, decoded: decoded}
+	}
+}
+
+// SetDecoded keeps records decoded in the background, unless the messages
+// were dropped or the deserializers changed since they were sent off.
+func (t *TopicViewModel) SetDecoded(msg RecordsDecodedMsg) {
+	if msg.generation != t.generation {
+		return
+	}
+	for r, d := range msg.decoded {
+		t.decoded[r] = d
+	}
+	if t.detail != nil && t.detail.decoded.pending {
+		if d, ok := msg.decoded[t.detail.record]; ok {
+			t.detail = newMessageDetail(t.detail.record, d, t.width, t.height)
+		}
+	}
+}
+
+// decodedFor returns r as decoded so far, pending until its batch is back.
+func (t *TopicViewModel) decodedFor(r *kgo.Record) decodedRecord {
+	if d, ok := t.decoded[r]; ok {
+		return d
+	}
+	return decodedRecord{keyFormat: t.keySerde, valueFormat: t.valueSerde, pending: true}
+}
+
+// Deserializers returns the names of the key and value deserializers in
+// use, so a download can read the topic the same way.
+func (t *TopicViewModel) Deserializers() (key, value string) {
+	return t.keySerde, t.valueSerde
+}
+
+func (t *TopicViewModel) openSerdePicker() {
+	t.choosingSerde = true
+	t.serdeField = 0
+	t.pendingSerde = [2]string{t.keySerde, t.valueSerde}
+}
+
+func (t *TopicViewModel) updateSerdePicker(msg tea.KeyMsg) tea.Cmd {
+	names := t.deserializers.Names()
+	cycle := func(delta int) {
+		current := 0
+		for i, name := range names {
+			if name == t.pendingSerde[t.serdeField] {
+				current = i
+			}
+		}
+		t.pendingSerde[t.serdeField] = names[(current+delta+len(names))%len(names)]
+	}
+
+	switch msg.String() {
+	case "tab", "shift+tab":
+		t.serdeField = 1 - t.serdeField
+	case "right", "l":
+		cycle(1)
+	case "left", "h":
+		cycle(-1)
+	case "enter":
+		t.choosingSerde = false
+		if t.pendingSerde == [2]string{t.keySerde, t.valueSerde} {
+			return nil
+		}
+		t.keySerde, t.valueSerde = t.pendingSerde[0], t.pendingSerde[1]
+		t.generation++
+		t.decoded = make(map[*kgo.Record]decodedRecord)
+		cmd := t.decodeCmd(t.messages)
+		if t.searchTerm != "" {
+			t.searchProgress = true
+			return tea.Batch(cmd, SearchMessagesCmd(t.topicName, t.messages, t.searchTerm, t.deserializers.Get(t.valueSerde)))
+		}
+		return cmd
+	case "esc":
+		t.choosingSerde = false
+	}
+	return nil
+}
+
+func (t *TopicViewModel) serdeBar() string {
+	label := lipgloss.NewStyle().Foreground(AccentColor).Padding(0, 1)
+
+	var bar string
+	for i, name := range []string{"Key:", "Value:"} {
+		style := TabStyle
+		if i == t.serdeField {
+			style = TabActiveStyle
+		}
+		bar += label.Render(name) + style.Render("‹ "+t.pendingSerde[i]+" ›")
+	}
+	return bar
+}
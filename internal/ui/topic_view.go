@@ -3,8 +3,10 @@ package ui
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/junegunn/fzf/src/algo"
 	"github.com/junegunn/fzf/src/util"
@@ -14,6 +16,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/twmb/franz-go/pkg/kgo"
+	"mojosoftware.dev/lazykafka/internal/serde"
 )
 
 var (
@@ -23,6 +26,9 @@ var (
 				Padding(0, 1).
 				MarginBottom(1)
 
+	selectedCardStyle = messageCardStyle.
+				BorderForeground(PrimaryColor)
+
 	messageHeaderStyle = lipgloss.NewStyle().
 				Foreground(lipgloss.Color("86")).
 				Bold(true)
@@ -56,6 +62,47 @@ type TopicViewModel struct {
 
 	searchProgress bool
 	filteredCache  []*kgo.Record
+
+	// cursor is the selected message's index into filteredMessages.
+	// cardOffsets holds the first line of each card on the page as last
+	// rendered, to scroll the selection into view.
+	cursor      int
+	cardOffsets []int
+	detail      *messageDetail
+
+	deserializers *serde.Registry
+	keySerde      string
+	valueSerde    string
+	decoded       map[*kgo.Record]decodedRecord
+	generation    int // bumped whenever decoded is reset
+	choosingSerde bool
+	serdeField    int // 0 for the key, 1 for the value
+	pendingSerde  [2]string
+
+	// Nothing is consumed until a start position is picked, which the
+	// picker asks for as soon as the view opens.
+	start     StartPosition
+	started   bool
+	picking   bool
+	pickKind  StartKind
+	pickInput textinput.Model
+	pickErr   string
+
+	// partitions limits reading to those partitions, nil for all of them.
+	partitions     []int32
+	counts         map[int32]int
+	choosingParts  bool
+	partitionInput textinput.Model
+	partitionErr   string
+}
+
+// ConsumeRequestedMsg asks the parent to (re)start consuming Topic from
+// Start, reading only Partitions unless it is nil. The view has already
+// dropped the messages it had.
+type ConsumeRequestedMsg struct {
+	Topic      string
+	Start      StartPosition
+	Partitions []int32
 }
 
 type SearchResultMsg struct {
@@ -63,7 +110,9 @@ type SearchResultMsg struct {
 	query   string
 }
 
-func SearchMessagesCmd(messages []*kgo.Record, query string) tea.Cmd {
+// SearchMessagesCmd fuzzy-matches query against each message's value, as
+// read by value, and its headers.
+func SearchMessagesCmd(topic string, messages []*kgo.Record, query string, value serde.Deserializer) tea.Cmd {
 	return func() tea.Msg {
 		if query == "" {
 			return SearchResultMsg{results: messages, query: query}
@@ -78,12 +127,25 @@ func SearchMessagesCmd(messages []*kgo.Record, query string) tea.Cmd {
 		matches := make([]scoredMatch, 0)
 
 		for _, msg := range messages {
-			valueStr := string(msg.Value)
-			input := util.RunesToChars([]rune(valueStr))
+			// A message matches on its value or any one header, scoring
+			// as its best match.
+			fields := make([]string, 0, 1+len(msg.Headers))
+			text, _ := decodePayload(value, topic, msg.Value)
+			fields = append(fields, text)
+			for _, h := range msg.Headers {
+				fields = append(fields, h.Key+"="+formatHeaderValue(h.Value))
+			}
 
-			result, _ := algo.FuzzyMatchV2(false, false, true, &input, pattern, false, nil)
-			if result.Start >= 0 {
-				matches = append(matches, scoredMatch{record: msg, score: result.Score})
+			best, found := 0, false
+			for _, field := range fields {
+				input := util.RunesToChars([]rune(field))
+				result, _ := algo.FuzzyMatchV2(false, false, true, &input, pattern, false, nil)
+				if result.Start >= 0 && (!found || result.Score > best) {
+					best, found = result.Score, true
+				}
+			}
+			if found {
+				matches = append(matches, scoredMatch{record: msg, score: best})
 			}
 		}
 		sort.Slice(matches, func(i, j int) bool {
@@ -97,12 +159,259 @@ func SearchMessagesCmd(messages []*kgo.Record, query string) tea.Cmd {
 	}
 }
 
-func (t *TopicViewModel) AddMessage(record *kgo.Record) {
-	t.messages = append(t.messages, record)
+// truncate cuts s to at most n bytes without splitting a character.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + "..."
 }
 
-func (t *TopicViewModel) AddMessages(records []*kgo.Record) {
+// AddMessage and AddMessages return the command that decodes the new
+// records.
+func (t *TopicViewModel) AddMessage(record *kgo.Record) tea.Cmd {
+	return t.AddMessages([]*kgo.Record{record})
+}
+
+func (t *TopicViewModel) AddMessages(records []*kgo.Record) tea.Cmd {
 	t.messages = append(t.messages, records...)
+	for _, r := range records {
+		t.counts[r.Partition]++
+	}
+	return t.decodeCmd(records)
+}
+
+// clearMessages drops everything read so far, along with any search over it.
+func (t *TopicViewModel) clearMessages() {
+	t.messages = make([]*kgo.Record, 0)
+	t.counts = make(map[int32]int)
+	t.filteredCache = nil
+	t.searchTerm = ""
+	t.searchInput.SetValue("")
+	t.searchProgress = false
+	t.currentPage = 0
+	t.cursor = 0
+	t.detail = nil
+	t.generation++
+	t.decoded = make(map[*kgo.Record]decodedRecord)
+	t.viewport.GotoTop()
+}
+
+// Typing reports whether keys are going to a text input or the message
+// detail, so the parent should not treat them as shortcuts, esc included.
+func (t *TopicViewModel) Typing() bool {
+	return t.searchMode || t.picking || t.choosingParts || t.choosingSerde || t.detail != nil
+}
+
+// consume drops what has been read and asks for the topic to be read again
+// with the current start position and partitions.
+func (t *TopicViewModel) consume() tea.Cmd {
+	t.started = true
+	t.clearMessages()
+	msg := ConsumeRequestedMsg{Topic: t.topicName, Start: t.start, Partitions: t.partitions}
+	return func() tea.Msg { return msg }
+}
+
+func (t *TopicViewModel) openPicker() tea.Cmd {
+	t.picking = true
+	t.pickErr = ""
+	t.setPickKind(t.start.Kind)
+	t.pickInput.SetValue(t.start.input)
+	if t.pickKind.needsValue() {
+		return t.pickInput.Focus()
+	}
+	return nil
+}
+
+func (t *TopicViewModel) setPickKind(k StartKind) {
+	t.pickKind = k
+	t.pickInput.SetValue("")
+	t.pickInput.Placeholder = k.placeholder()
+	t.pickErr = ""
+	if !k.needsValue() {
+		t.pickInput.Blur()
+	}
+}
+
+func (t *TopicViewModel) updatePicker(msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "tab", "shift+tab":
+		if msg.String() == "tab" {
+			t.setPickKind((t.pickKind + 1) % startKindCount)
+		} else {
+			t.setPickKind((t.pickKind - 1 + startKindCount) % startKindCount)
+		}
+		if t.pickKind.needsValue() {
+			return t.pickInput.Focus()
+		}
+		return nil
+	case "enter":
+		start, err := parseStartPosition(t.pickKind, t.pickInput.Value(), time.Now())
+		if err != nil {
+			t.pickErr = err.Error()
+			return nil
+		}
+		t.picking = false
+		t.pickInput.Blur()
+		t.start = start
+		return t.consume()
+	case "esc":
+		t.picking = false
+		t.pickInput.Blur()
+		if !t.started {
+			return func() tea.Msg { return BackMsg{} }
+		}
+		return nil
+	}
+
+	if t.pickKind.needsValue() {
+		t.pickInput, cmd = t.pickInput.Update(msg)
+	}
+	return cmd
+}
+
+func (t *TopicViewModel) openPartitions() tea.Cmd {
+	t.choosingParts = true
+	t.partitionErr = ""
+	t.partitionInput.SetValue(formatPartitions(t.partitions))
+	t.partitionInput.CursorEnd()
+	return t.partitionInput.Focus()
+}
+
+func (t *TopicViewModel) updatePartitions(msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "enter":
+		partitions, err := parsePartitions(t.partitionInput.Value())
+		if err != nil {
+			t.partitionErr = err.Error()
+			return nil
+		}
+		t.choosingParts = false
+		t.partitionInput.Blur()
+		t.partitions = partitions
+		return t.consume()
+	case "esc":
+		t.choosingParts = false
+		t.partitionInput.Blur()
+		return nil
+	}
+
+	t.partitionInput, cmd = t.partitionInput.Update(msg)
+	return cmd
+}
+
+// maxPartitionRange bounds a single range so a typo such as 0-99999999
+// can't stall the view.
+const maxPartitionRange = 10000
+
+// parsePartitions parses a list such as "0,2,5-7". An empty list means
+// every partition and returns nil.
+func parsePartitions(value string) ([]int32, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "all" {
+		return nil, nil
+	}
+
+	seen := make(map[int32]bool)
+	var partitions []int32
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		lo, hi, isRange := strings.Cut(entry, "-")
+		first, err := strconv.ParseInt(strings.TrimSpace(lo), 10, 32)
+		if err != nil || first < 0 {
+			return nil, fmt.Errorf("partition must be a non-negative integer, got %q", entry)
+		}
+		last := first
+		if isRange {
+			last, err = strconv.ParseInt(strings.TrimSpace(hi), 10, 32)
+			if err != nil || last < first {
+				return nil, fmt.Errorf("%q is not a valid partition range", entry)
+			}
+		}
+		if last-first >= maxPartitionRange {
+			return nil, fmt.Errorf("%q covers more than %d partitions", entry, maxPartitionRange)
+		}
+		for p := first; p <= last; p++ {
+			if !seen[int32(p)] {
+				seen[int32(p)] = true
+				partitions = append(partitions, int32(p))
+			}
+		}
+	}
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
+	return partitions, nil
+}
+
+func formatPartitions(partitions []int32) string {
+	parts := make([]string, len(partitions))
+	for i, p := range partitions {
+		parts[i] = strconv.Itoa(int(p))
+	}
+	return strings.Join(parts, ",")
+}
+
+// partitionsLine shows which partitions are read and how many messages
+// came from each, cut to the view's width.
+func (t *TopicViewModel) partitionsLine() string {
+	line := fmt.Sprintf("Key: %s • Value: %s • Partitions: all", t.keySerde, t.valueSerde)
+	if t.partitions != nil {
+		line = fmt.Sprintf("Key: %s • Value: %s • Partitions: %s", t.keySerde, t.valueSerde, formatPartitions(t.partitions))
+	}
+
+	partitions := make([]int32, 0, len(t.counts))
+	for p := range t.counts {
+		partitions = append(partitions, p)
+	}
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
+	for _, p := range partitions {
+		line += fmt.Sprintf(" • p%d: %d", p, t.counts[p])
+	}
+
+	if maxWidth := t.width - 2; maxWidth > 1 && lipgloss.Width(line) > maxWidth {
+		line = string([]rune(line)[:maxWidth-1]) + "…"
+	}
+	return lipgloss.NewStyle().Foreground(SubtleColor).Padding(0, 1).Render(line)
+}
+
+// moveCursor selects another message on the current page and scrolls so
+// that all of its card is visible.
+func (t *TopicViewModel) moveCursor(delta int) {
+	start := t.currentPage * t.pageSize
+	end := min(start+t.pageSize, len(t.filteredMessages()))
+	if end <= start {
+		return
+	}
+	t.cursor = min(max(t.cursor+delta, start), end-1)
+
+	i := t.cursor - start
+	if i >= len(t.cardOffsets) {
+		return
+	}
+	top := t.cardOffsets[i]
+	bottom := t.viewport.TotalLineCount()
+	if i+1 < len(t.cardOffsets) {
+		bottom = t.cardOffsets[i+1]
+	}
+	if top < t.viewport.YOffset {
+		t.viewport.SetYOffset(top)
+	} else if bottom > t.viewport.YOffset+t.viewport.Height {
+		t.viewport.SetYOffset(min(bottom-t.viewport.Height, top))
+	}
+}
+
+func (t *TopicViewModel) openDetail() {
+	filtered := t.filteredMessages()
+	if t.cursor < 0 || t.cursor >= len(filtered) {
+		return
+	}
+	t.detail = newMessageDetail(filtered[t.cursor], t.decodedFor(filtered[t.cursor]), t.width, t.height)
 }
 
 func (t *TopicViewModel) filteredMessages() []*kgo.Record {
@@ -123,6 +432,7 @@ func (t *TopicViewModel) totalPages() int {
 func (t *TopicViewModel) nextPage() {
 	if t.currentPage < t.totalPages()-1 {
 		t.currentPage++
+		t.cursor = t.currentPage * t.pageSize
 		t.viewport.GotoTop()
 	}
 }
@@ -130,19 +440,27 @@ func (t *TopicViewModel) nextPage() {
 func (t *TopicViewModel) prevPage() {
 	if t.currentPage > 0 {
 		t.currentPage--
+		t.cursor = t.currentPage * t.pageSize
 		t.viewport.GotoTop()
 	}
 }
 
-func NewTopicViewModel(topicName string, width, height int) *TopicViewModel {
-	vp := viewport.New(width, height-6)
+func NewTopicViewModel(topicName string, deserializers *serde.Registry, width, height int) *TopicViewModel {
+	vp := viewport.New(width, height-7)
 	vp.SetContent("")
 
 	searchInput := textinput.New()
-	searchInput.Placeholder = "Search in keys or values..."
+	searchInput.Placeholder = "Search in values or headers..."
 	searchInput.Width = 50
 
-	return &TopicViewModel{
+	pickInput := textinput.New()
+	pickInput.Width = 50
+
+	partitionInput := textinput.New()
+	partitionInput.Placeholder = "e.g. 0,2,5-7, empty for all"
+	partitionInput.Width = 50
+
+	vm := &TopicViewModel{
 		topicName:   topicName,
 		messages:    make([]*kgo.Record, 0),
 		viewport:    vp,
@@ -153,7 +471,18 @@ func NewTopicViewModel(topicName string, width, height int) *TopicViewModel {
 		searchMode:  false,
 		searchInput: searchInput,
 		searchTerm:  "",
+		pickInput:   pickInput,
+
+		counts:         make(map[int32]int),
+		partitionInput: partitionInput,
+
+		deserializers: deserializers,
+		keySerde:      serde.Auto,
+		valueSerde:    serde.Auto,
+		decoded:       make(map[*kgo.Record]decodedRecord),
 	}
+	vm.openPicker()
+	return vm
 }
 
 func (t *TopicViewModel) Init() tea.Cmd {
@@ -166,9 +495,12 @@ func (t *TopicViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		t.viewport.Width = msg.Width
-		t.viewport.Height = msg.Height - 6
+		t.viewport.Height = msg.Height - 7
 		t.width = msg.Width
 		t.height = msg.Height
+		if t.detail != nil {
+			t.detail.resize(msg.Width, msg.Height)
+		}
 
 	case SearchResultMsg:
 		if msg.query == t.searchTerm {
@@ -177,6 +509,23 @@ func (t *TopicViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return t, nil
 	case tea.KeyMsg:
+		if t.detail != nil {
+			if msg.String() == "esc" {
+				t.detail = nil
+				return t, nil
+			}
+			return t, t.detail.Update(msg)
+		}
+		if t.picking {
+			return t, t.updatePicker(msg)
+		}
+		if t.choosingParts {
+			return t, t.updatePartitions(msg)
+		}
+		if t.choosingSerde {
+			return t, t.updateSerdePicker(msg)
+		}
+
 		if t.searchMode {
 			switch msg.String() {
 			case "enter":
@@ -184,7 +533,8 @@ func (t *TopicViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				t.searchMode = false
 				t.searchProgress = true
 				t.currentPage = 0
-				return t, SearchMessagesCmd(t.messages, t.searchTerm)
+				t.cursor = 0
+				return t, SearchMessagesCmd(t.topicName, t.messages, t.searchTerm, t.deserializers.Get(t.valueSerde))
 			case "esc":
 				t.searchMode = false
 				t.searchInput.SetValue("")
@@ -201,6 +551,13 @@ func (t *TopicViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			t.searchInput.Focus()
 			t.searchInput.SetValue("")
 			return t, textinput.Blink
+		case "s":
+			return t, t.openPicker()
+		case "f":
+			return t, t.openPartitions()
+		case "d":
+			t.openSerdePicker()
+			return t, nil
 		case "n":
 			t.nextPage()
 			return t, nil
@@ -212,12 +569,25 @@ func (t *TopicViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				t.searchTerm = ""
 				t.searchInput.SetValue("")
 				t.currentPage = 0
+				t.cursor = 0
 				return t, nil
 			}
+		case "up", "k":
+			t.moveCursor(-1)
+			return t, nil
+		case "down", "j":
+			t.moveCursor(1)
+			return t, nil
+		case "enter":
+			t.openDetail()
+			return t, nil
 		case "g":
+			t.cursor = t.currentPage * t.pageSize
 			t.viewport.GotoTop()
 			return t, nil
 		case "G":
+			t.cursor = t.currentPage*t.pageSize + t.pageSize - 1
+			t.moveCursor(0)
 			t.viewport.GotoBottom()
 			return t, nil
 		default:
@@ -229,7 +599,13 @@ func (t *TopicViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (t *TopicViewModel) renderMessages() string {
+	if !t.started {
+		return emptyStateStyle.Render("Pick where to start reading the topic.")
+	}
 	if len(t.messages) == 0 {
+		if t.start.Kind == StartLatest {
+			return emptyStateStyle.Render("⏳ Waiting for new messages...")
+		}
 		return emptyStateStyle.Render("⏳ Waiting for messages...")
 	}
 
@@ -256,7 +632,10 @@ func (t *TopicViewModel) renderMessages() string {
 			end = len(filtered)
 		}
 	}
+	t.cursor = min(max(t.cursor, start), end-1)
 
+	t.cardOffsets = t.cardOffsets[:0]
+	line := 0
 	for i := start; i < end; i++ {
 		record := filtered[i]
 		originalIdx := -1
@@ -284,24 +663,48 @@ func (t *TopicViewModel) renderMessages() string {
 			messageLabelStyle.Render("Offset:"),
 			messageValueStyle.Render(fmt.Sprintf("%d", record.Offset)))
 
-		keyStr := string(record.Key)
-		if keyStr == "" {
-			keyStr = "(null)"
+		decoded := t.decodedFor(record)
+		keyStr := messageValueStyle.Render(truncate(decoded.key, 200))
+		if decoded.keyErr != nil {
+			keyStr = partitionErrorStyle.Render(decoded.keyErr.Error())
+		} else if decoded.pending && len(record.Key) > 0 {
+			keyStr = emptyValueStyle.Render("decoding…")
+		} else if decoded.key == "" {
+			keyStr = messageValueStyle.Render("(null)")
 		}
 		meta += fmt.Sprintf("%s %s\n",
 			messageLabelStyle.Render("Key:"),
-			messageValueStyle.Render(keyStr))
+			keyStr)
 
-		valueStr := string(record.Value)
-		if len(valueStr) > 200 {
-			valueStr = valueStr[:200] + "..."
+		valueStr := messageValueStyle.Render(truncate(decoded.value, 200))
+		if decoded.valueErr != nil {
+			valueStr = partitionErrorStyle.Render(decoded.valueErr.Error())
+		} else if decoded.pending && len(record.Value) > 0 {
+			valueStr = emptyValueStyle.Render("decoding…")
 		}
 		meta += fmt.Sprintf("%s %s",
 			messageLabelStyle.Render("Value:"),
-			messageValueStyle.Render(valueStr))
+			valueStr)
+
+		if len(record.Headers) > 0 {
+			headers := make([]string, len(record.Headers))
+			for i, h := range record.Headers {
+				headers[i] = h.Key + "=" + formatHeaderValue(h.Value)
+			}
+			headerStr := truncate(strings.Join(headers, ", "), 200)
+			meta += fmt.Sprintf("\n%s %s",
+				messageLabelStyle.Render("Headers:"),
+				messageValueStyle.Render(headerStr))
+		}
 
 		cardContent := lipgloss.JoinVertical(lipgloss.Left, header, meta)
-		card := messageCardStyle.Width(cardWidth).Render(cardContent)
+		style := messageCardStyle
+		if i == t.cursor {
+			style = selectedCardStyle
+		}
+		card := style.Width(cardWidth).Render(cardContent)
+		t.cardOffsets = append(t.cardOffsets, line)
+		line += lipgloss.Height(card)
 		content.WriteString(card)
 		content.WriteString("\n")
 	}
@@ -310,10 +713,17 @@ func (t *TopicViewModel) renderMessages() string {
 }
 
 func (t *TopicViewModel) View() string {
+	if t.detail != nil {
+		return t.detail.View(t.topicName)
+	}
+
 	t.viewport.SetContent(t.renderMessages())
 
 	filtered := t.filteredMessages()
 	headerText := fmt.Sprintf("📨 %s • %d total", t.topicName, len(t.messages))
+	if t.started {
+		headerText += " • " + t.start.String()
+	}
 
 	if t.searchTerm != "" {
 		headerText += fmt.Sprintf(" • %d filtered", len(filtered))
@@ -327,7 +737,21 @@ func (t *TopicViewModel) View() string {
 	header := HeaderStyle.Width(t.width).Render(headerText)
 
 	var searchBar string
-	if t.searchMode {
+	if t.picking {
+		searchBar = t.pickerBar()
+	} else if t.choosingSerde {
+		searchBar = t.serdeBar()
+	} else if t.choosingParts {
+		searchBar = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("86")).
+			Render("Partitions: ") + t.partitionInput.View()
+		if t.partitionErr != "" {
+			searchBar = lipgloss.JoinVertical(lipgloss.Left, searchBar, partitionErrorStyle.Render("✗ "+t.partitionErr))
+		}
+		searchBar = lipgloss.NewStyle().
+			Padding(0, 1).
+			Render(searchBar)
+	} else if t.searchMode {
 		searchBar = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("86")).
 			Render("Search: ") + t.searchInput.View()
@@ -350,10 +774,17 @@ func (t *TopicViewModel) View() string {
 
 	scrollPercent := fmt.Sprintf("%3.f%%", t.viewport.ScrollPercent()*100)
 	help := HelpStyle.Render(fmt.Sprintf(
-		"↑/↓ j/k: scroll • g/G: top/bottom • n/p: next/prev page • /: search • c: clear search • %s • esc: back",
+		"↑/↓ j/k: select • enter: details • g/G: top/bottom • n/p: page • /: search • c: clear search • s: start • f: partitions • d: deserializers • %s • esc: back",
 		scrollPercent))
+	if t.picking {
+		help = HelpStyle.Render("tab/shift+tab: start position • enter: start reading • esc: cancel")
+	} else if t.choosingParts {
+		help = HelpStyle.Render("enter: read these partitions • esc: cancel")
+	} else if t.choosingSerde {
+		help = HelpStyle.Render("tab: key/value • ←/→ h/l: deserializer • enter: apply • esc: cancel")
+	}
 
-	parts := []string{header}
+	parts := []string{header, t.partitionsLine()}
 	if searchBar != "" {
 		parts = append(parts, searchBar)
 	}
@@ -364,3 +795,16 @@ func (t *TopicViewModel) View() string {
 		parts...,
 	)
 }
+
+func (t *TopicViewModel) pickerBar() string {
+	label := lipgloss.NewStyle().Foreground(AccentColor).Padding(0, 1)
+
+	bar := label.Render("Start:") + TabActiveStyle.Render("‹ "+t.pickKind.String()+" ›")
+	if t.pickKind.needsValue() {
+		bar += label.Render("Value:") + t.pickInput.View()
+	}
+	if t.pickErr != "" {
+		bar = lipgloss.JoinVertical(lipgloss.Left, bar, partitionErrorStyle.Padding(0, 1).Render("✗ "+t.pickErr))
+	}
+	return bar
+}
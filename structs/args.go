@@ -0,0 +1,8 @@
+package structs
+
+// Args holds the parsed command line arguments.
+type Args struct {
+	ConfigPath string
+	Profile    string
+	Bootstrap  string
+}